@@ -0,0 +1,124 @@
+package blnkgo
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+type APIKeyService service
+
+// apiKeyScopeRegex matches scopes of the form resource:action, * is allowed for either part
+var apiKeyScopeRegex = regexp.MustCompile(`^([a-z_-]+|\*):([a-z_-]+|\*)$`)
+
+// CreateAPIKeyRequest describes a scoped API key to issue.
+// Scopes take the form "resource:action", for example "transactions:write" or "balances:read".
+type CreateAPIKeyRequest struct {
+	Name      string    `json:"name"`
+	Owner     string    `json:"owner"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// APIKey is an issued API key. Key holds the secret and is only returned by Create.
+type APIKey struct {
+	APIKeyID  string     `json:"api_key_id"`
+	Key       string     `json:"key,omitempty"`
+	Name      string     `json:"name"`
+	Owner     string     `json:"owner"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	LastUsed  *time.Time `json:"last_used_at,omitempty"`
+	IsRevoked bool       `json:"is_revoked"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// ListAPIKeysParams filters the keys returned by List
+type ListAPIKeysParams struct {
+	Owner string `url:"owner,omitempty"`
+}
+
+func validateCreateAPIKey(body CreateAPIKeyRequest) error {
+	verr := &ValidationError{}
+	if body.Name == "" {
+		verr.Add("name", ValidationCodeRequired, "name is required")
+	}
+	if body.Owner == "" {
+		verr.Add("owner", ValidationCodeRequired, "owner is required")
+	}
+	if len(body.Scopes) == 0 {
+		verr.Add("scopes", ValidationCodeRequired, "at least one scope is required")
+	}
+	for i, scope := range body.Scopes {
+		if !apiKeyScopeRegex.MatchString(scope) {
+			verr.Add(fmt.Sprintf("scopes[%d]", i), ValidationCodeInvalid, "scope must take the form resource:action")
+		}
+	}
+	if body.ExpiresAt.IsZero() {
+		verr.Add("expires_at", ValidationCodeRequired, "expires_at is required")
+	} else if !body.ExpiresAt.After(time.Now()) {
+		verr.Add("expires_at", ValidationCodeInvalid, "expires_at must be in the future")
+	}
+	return verr.ErrOrNil()
+}
+
+func (s *APIKeyService) Create(body CreateAPIKeyRequest) (*APIKey, *http.Response, error) {
+	if err := requireFeature(s.client, FeatureAPIKeys); err != nil {
+		return nil, nil, err
+	}
+	if err := validateCreateAPIKey(body); err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("api-keys", http.MethodPost, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiKey := new(APIKey)
+	resp, err := s.client.CallWithRetry(req, apiKey)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return apiKey, resp, nil
+}
+
+func (s *APIKeyService) List(params ListAPIKeysParams) ([]APIKey, *http.Response, error) {
+	if err := requireFeature(s.client, FeatureAPIKeys); err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("api-keys", http.MethodGet, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var apiKeys []APIKey
+	resp, err := s.client.CallWithRetry(req, &apiKeys)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return apiKeys, resp, nil
+}
+
+// Revoke invalidates a key immediately, it can not be reinstated
+func (s *APIKeyService) Revoke(apiKeyID string) (*http.Response, error) {
+	if err := requireFeature(s.client, FeatureAPIKeys); err != nil {
+		return nil, err
+	}
+	if apiKeyID == "" {
+		return nil, fmt.Errorf("apiKeyID is required")
+	}
+	req, err := s.client.NewRequest("api-keys/"+apiKeyID, http.MethodDelete, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.CallWithRetry(req, nil)
+}
+
+func NewAPIKeyService(client ClientInterface) *APIKeyService {
+	return &APIKeyService{client: client}
+}
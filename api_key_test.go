@@ -0,0 +1,97 @@
+package blnkgo_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupAPIKeyService() (*MockClient, *blnkgo.APIKeyService) {
+	mockClient := &MockClient{}
+	svc := blnkgo.NewAPIKeyService(mockClient)
+	return mockClient, svc
+}
+
+func TestAPIKeyService_Create_Success(t *testing.T) {
+	mockClient, svc := setupAPIKeyService()
+	body := blnkgo.CreateAPIKeyRequest{
+		Name:      "tenant-42 provisioning",
+		Owner:     "tenant-42",
+		Scopes:    []string{"transactions:write", "balances:read"},
+		ExpiresAt: time.Now().Add(90 * 24 * time.Hour),
+	}
+
+	mockClient.On("NewRequest", "api-keys", http.MethodPost, body).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusCreated}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.APIKey) = blnkgo.APIKey{APIKeyID: "key-1", Key: "blnk_secret", Name: body.Name, Owner: body.Owner, Scopes: body.Scopes}
+	})
+
+	apiKey, resp, err := svc.Create(body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "blnk_secret", apiKey.Key)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAPIKeyService_Create_ValidationError(t *testing.T) {
+	mockClient, svc := setupAPIKeyService()
+
+	apiKey, resp, err := svc.Create(blnkgo.CreateAPIKeyRequest{
+		Scopes:    []string{"transactions"},
+		ExpiresAt: time.Now().Add(-time.Hour),
+	})
+	assert.Nil(t, apiKey)
+	assert.Nil(t, resp)
+
+	var verr *blnkgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	for _, field := range []string{"name", "owner", "scopes[0]", "expires_at"} {
+		assert.True(t, verr.HasField(field), field)
+	}
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
+
+func TestAPIKeyService_List_Success(t *testing.T) {
+	mockClient, svc := setupAPIKeyService()
+	params := blnkgo.ListAPIKeysParams{Owner: "tenant-42"}
+
+	expected := []blnkgo.APIKey{{APIKeyID: "key-1", Owner: "tenant-42"}}
+	mockClient.On("NewRequest", "api-keys", http.MethodGet, params).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]blnkgo.APIKey) = expected
+	})
+
+	keys, _, err := svc.List(params)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, keys)
+	mockClient.AssertExpectations(t)
+}
+
+func TestAPIKeyService_List_ServerError(t *testing.T) {
+	mockClient, svc := setupAPIKeyService()
+
+	mockClient.On("NewRequest", "api-keys", http.MethodGet, blnkgo.ListAPIKeysParams{}).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusInternalServerError}, errors.New("server error"))
+
+	keys, resp, err := svc.List(blnkgo.ListAPIKeysParams{})
+	assert.Error(t, err)
+	assert.Nil(t, keys)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	mockClient, svc := setupAPIKeyService()
+
+	mockClient.On("NewRequest", "api-keys/key-1", http.MethodDelete, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, nil).Return(&http.Response{StatusCode: http.StatusNoContent}, nil)
+
+	resp, err := svc.Revoke("key-1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	mockClient.AssertExpectations(t)
+}
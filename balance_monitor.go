@@ -1,6 +1,12 @@
 package blnkgo
 
-import "net/http"
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+)
 
 type BalanceMonitorService service
 
@@ -11,12 +17,22 @@ type MonitorCondition struct {
 	Precision int64                     `json:"precision"`
 }
 
+// MonitorConditionGroup joins conditions and nested groups with AND or OR, so a single
+// monitor can watch several balance fields at once.
+type MonitorConditionGroup struct {
+	Operator   MonitorGroupOperator    `json:"operator"`
+	Conditions []MonitorCondition      `json:"conditions,omitempty"`
+	Groups     []MonitorConditionGroup `json:"groups,omitempty"`
+}
+
 // MonitorData represents the data structure for monitoring information.
+// Set either Condition or ConditionGroup, not both.
 type MonitorData struct {
-	Condition   MonitorCondition `json:"condition"`
-	Description string           `json:"description,omitempty"`
-	BalanceID   string           `json:"balance_id"`
-	CallBackURL string           `json:"call_back_url,omitempty"`
+	Condition      MonitorCondition       `json:"condition"`
+	ConditionGroup *MonitorConditionGroup `json:"condition_group,omitempty"`
+	Description    string                 `json:"description,omitempty"`
+	BalanceID      string                 `json:"balance_id"`
+	CallBackURL    string                 `json:"call_back_url,omitempty"`
 }
 
 // MonitorDataResp extends MonitorData with additional fields for response data.
@@ -26,7 +42,41 @@ type MonitorDataResp struct {
 	CreatedAt string `json:"created_at"` // ISO date string
 }
 
+// plainMonitorData has the fields of MonitorData without its MarshalJSON
+type plainMonitorData MonitorData
+
+// monitorDataJSON is the wire form of MonitorData, the single condition is left out when a
+// condition group is set so the server never sees an empty condition next to the group
+type monitorDataJSON struct {
+	plainMonitorData
+	Condition *MonitorCondition `json:"condition,omitempty"`
+}
+
+func (m MonitorData) toJSON() monitorDataJSON {
+	out := monitorDataJSON{plainMonitorData: plainMonitorData(m)}
+	if m.ConditionGroup == nil {
+		out.Condition = &m.Condition
+	}
+	return out
+}
+
+func (m MonitorData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.toJSON())
+}
+
+// MarshalJSON keeps the response fields, which the promoted MonitorData.MarshalJSON would drop
+func (m MonitorDataResp) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		monitorDataJSON
+		MonitorID string `json:"monitor_id"`
+		CreatedAt string `json:"created_at"`
+	}{m.MonitorData.toJSON(), m.MonitorID, m.CreatedAt})
+}
+
 func (s *BalanceMonitorService) Create(data MonitorData) (*MonitorDataResp, *http.Response, error) {
+	if err := data.Validate(); err != nil {
+		return nil, nil, err
+	}
 	req, err := s.client.NewRequest("balance-monitors", http.MethodPost, data)
 	if err != nil {
 		return nil, nil, err
@@ -72,6 +122,9 @@ func (s *BalanceMonitorService) List() ([]MonitorDataResp, *http.Response, error
 }
 
 func (s *BalanceMonitorService) Update(monitorID string, data MonitorData) (*MonitorDataResp, *http.Response, error) {
+	if err := data.Validate(); err != nil {
+		return nil, nil, err
+	}
 	req, err := s.client.NewRequest("balance-monitors/"+monitorID, http.MethodPut, data)
 	if err != nil {
 		return nil, nil, err
@@ -86,6 +139,208 @@ func (s *BalanceMonitorService) Update(monitorID string, data MonitorData) (*Mon
 	return monitorData, resp, nil
 }
 
+// ListByBalance lists the monitors attached to a single balance
+func (s *BalanceMonitorService) ListByBalance(balanceID string) ([]MonitorDataResp, *http.Response, error) {
+	if balanceID == "" {
+		return nil, nil, fmt.Errorf("balanceID is required")
+	}
+	req, err := s.client.NewRequest("balance-monitors/balances/"+balanceID, http.MethodGet, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var monitorData []MonitorDataResp
+	resp, err := s.client.CallWithRetry(req, &monitorData)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return monitorData, resp, nil
+}
+
+func (s *BalanceMonitorService) Delete(monitorID string) (*http.Response, error) {
+	if monitorID == "" {
+		return nil, fmt.Errorf("monitorID is required")
+	}
+	req, err := s.client.NewRequest("balance-monitors/"+monitorID, http.MethodDelete, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.CallWithRetry(req, nil)
+}
+
+// monitorBalanceFields are the LedgerBalance fields a monitor condition can watch
+var monitorBalanceFields = []string{
+	"balance",
+	"credit_balance",
+	"debit_balance",
+	"inflight_balance",
+	"inflight_credit_balance",
+	"inflight_debit_balance",
+}
+
+// Validate returns a *ValidationError listing every problem with the monitor, so bad
+// field names or operators are caught before they reach the server.
+func (d MonitorData) Validate() error {
+	verr := &ValidationError{}
+	if d.BalanceID == "" {
+		verr.Add("balance_id", ValidationCodeRequired, "balance_id is required")
+	}
+
+	hasCondition := d.Condition != (MonitorCondition{})
+	switch {
+	case hasCondition && d.ConditionGroup != nil:
+		verr.Add("condition_group", ValidationCodeConflict, "you can not use both condition and condition_group")
+	case hasCondition:
+		validateMonitorCondition("condition", d.Condition, verr)
+	case d.ConditionGroup != nil:
+		validateMonitorConditionGroup("condition_group", *d.ConditionGroup, verr)
+	default:
+		verr.Add("condition", ValidationCodeRequired, "condition or condition_group is required")
+	}
+	return verr.ErrOrNil()
+}
+
+func validateMonitorCondition(field string, c MonitorCondition, verr *ValidationError) {
+	if c.Field == "" {
+		verr.Add(field+".field", ValidationCodeRequired, "field is required")
+	} else if !isMonitorBalanceField(c.Field) {
+		verr.Add(field+".field", ValidationCodeInvalid, fmt.Sprintf("field must be one of %s", strings.Join(monitorBalanceFields, ", ")))
+	}
+	switch c.Operator {
+	case OperatorGreaterThan, OperatorLessThan, OperatorEqualTo, OperatorNotEqualTo, OperatorGreaterThanOrEqual, OperatorLessThanOrEqual:
+	case "":
+		verr.Add(field+".operator", ValidationCodeRequired, "operator is required")
+	default:
+		verr.Add(field+".operator", ValidationCodeInvalid, fmt.Sprintf("unknown operator: %s", c.Operator))
+	}
+	if c.Precision < 0 {
+		verr.Add(field+".precision", ValidationCodeNegative, "precision can not be negative")
+	}
+}
+
+func validateMonitorConditionGroup(field string, g MonitorConditionGroup, verr *ValidationError) {
+	if g.Operator != GroupOperatorAnd && g.Operator != GroupOperatorOr {
+		verr.Add(field+".operator", ValidationCodeInvalid, "operator must be AND or OR")
+	}
+	if len(g.Conditions) == 0 && len(g.Groups) == 0 {
+		verr.Add(field+".conditions", ValidationCodeRequired, "a group needs at least one condition or group")
+	}
+	for i, c := range g.Conditions {
+		validateMonitorCondition(fmt.Sprintf("%s.conditions[%d]", field, i), c, verr)
+	}
+	for i, sub := range g.Groups {
+		validateMonitorConditionGroup(fmt.Sprintf("%s.groups[%d]", field, i), sub, verr)
+	}
+}
+
+func isMonitorBalanceField(field string) bool {
+	for _, f := range monitorBalanceFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// balanceFieldValue returns the named field of b in its smallest unit
+func balanceFieldValue(b *LedgerBalance, field string) (int64, error) {
+	switch field {
+	case "balance":
+		return int64(b.Balance), nil
+	case "credit_balance":
+		return int64(b.CreditBalance), nil
+	case "debit_balance":
+		return int64(b.DebitBalance), nil
+	case "inflight_balance":
+		return int64(b.InflightBalance), nil
+	case "inflight_credit_balance":
+		return int64(b.InflightCreditBalance), nil
+	case "inflight_debit_balance":
+		return int64(b.InflightDebitBalance), nil
+	}
+	return 0, fmt.Errorf("unknown balance field: %s", field)
+}
+
+// Evaluate reports whether the condition currently holds for balance, the same way the
+// server decides whether to fire the monitor. Value is scaled by Precision before being
+// compared with the balance field, which is stored in its smallest unit.
+func (c MonitorCondition) Evaluate(balance *LedgerBalance) (bool, error) {
+	if balance == nil {
+		return false, fmt.Errorf("balance is required")
+	}
+	actual, err := balanceFieldValue(balance, c.Field)
+	if err != nil {
+		return false, err
+	}
+
+	precision := c.Precision
+	if precision <= 0 {
+		precision = 1
+	}
+	//compare as big ints so large values scaled by precision can not overflow
+	threshold := new(big.Int).Mul(big.NewInt(c.Value), big.NewInt(precision))
+	cmp := big.NewInt(actual).Cmp(threshold)
+
+	switch c.Operator {
+	case OperatorGreaterThan:
+		return cmp > 0, nil
+	case OperatorLessThan:
+		return cmp < 0, nil
+	case OperatorEqualTo:
+		return cmp == 0, nil
+	case OperatorNotEqualTo:
+		return cmp != 0, nil
+	case OperatorGreaterThanOrEqual:
+		return cmp >= 0, nil
+	case OperatorLessThanOrEqual:
+		return cmp <= 0, nil
+	}
+	return false, fmt.Errorf("unknown operator: %s", c.Operator)
+}
+
+// Evaluate reports whether the group currently holds for balance. AND groups stop at the
+// first condition that does not hold and OR groups at the first one that does.
+func (g MonitorConditionGroup) Evaluate(balance *LedgerBalance) (bool, error) {
+	if g.Operator != GroupOperatorAnd && g.Operator != GroupOperatorOr {
+		return false, fmt.Errorf("unknown group operator: %s", g.Operator)
+	}
+	if len(g.Conditions) == 0 && len(g.Groups) == 0 {
+		return false, fmt.Errorf("condition group is empty")
+	}
+
+	results := make([]func() (bool, error), 0, len(g.Conditions)+len(g.Groups))
+	for _, c := range g.Conditions {
+		results = append(results, func() (bool, error) { return c.Evaluate(balance) })
+	}
+	for _, sub := range g.Groups {
+		results = append(results, func() (bool, error) { return sub.Evaluate(balance) })
+	}
+
+	for _, result := range results {
+		ok, err := result()
+		if err != nil {
+			return false, err
+		}
+		if g.Operator == GroupOperatorOr && ok {
+			return true, nil
+		}
+		if g.Operator == GroupOperatorAnd && !ok {
+			return false, nil
+		}
+	}
+	return g.Operator == GroupOperatorAnd, nil
+}
+
+// Evaluate reports whether the monitor's condition or condition group holds for balance
+func (d MonitorData) Evaluate(balance *LedgerBalance) (bool, error) {
+	if d.ConditionGroup != nil {
+		return d.ConditionGroup.Evaluate(balance)
+	}
+	return d.Condition.Evaluate(balance)
+}
+
 func NewBalanceMonitorService(client ClientInterface) *BalanceMonitorService {
 
 	return &BalanceMonitorService{client: client}
@@ -1,7 +1,9 @@
 package blnkgo_test
 
 import (
+	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"testing"
 	"time"
@@ -9,6 +11,7 @@ import (
 	blnkgo "github.com/blnkfinance/blnk-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func setupBalanceMonitorService() (*MockClient, *blnkgo.BalanceMonitorService) {
@@ -23,7 +26,7 @@ func TestBalanceMonitorService_Create_Success(t *testing.T) {
 	data := blnkgo.MonitorData{
 		Condition: blnkgo.MonitorCondition{
 			Field:     "balance",
-			Operator:  blnkgo.MonitorConditionOperators("greater_than"),
+			Operator:  blnkgo.OperatorGreaterThan,
 			Value:     1000,
 			Precision: 2,
 		},
@@ -59,7 +62,7 @@ func TestBalanceMonitorService_Create_RequestCreationFailure(t *testing.T) {
 	data := blnkgo.MonitorData{
 		Condition: blnkgo.MonitorCondition{
 			Field:     "balance",
-			Operator:  blnkgo.MonitorConditionOperators("greater_than"),
+			Operator:  blnkgo.OperatorGreaterThan,
 			Value:     1000,
 			Precision: 2,
 		},
@@ -85,7 +88,7 @@ func TestBalanceMonitorService_Create_ServerError(t *testing.T) {
 	data := blnkgo.MonitorData{
 		Condition: blnkgo.MonitorCondition{
 			Field:     "balance",
-			Operator:  blnkgo.MonitorConditionOperators("greater_than"),
+			Operator:  blnkgo.OperatorGreaterThan,
 			Value:     1000,
 			Precision: 2,
 		},
@@ -231,7 +234,7 @@ func TestBalanceMonitorService_Update_Success(t *testing.T) {
 	data := blnkgo.MonitorData{
 		Condition: blnkgo.MonitorCondition{
 			Field:     "balance",
-			Operator:  blnkgo.MonitorConditionOperators("greater_than"),
+			Operator:  blnkgo.OperatorGreaterThan,
 			Value:     1000,
 			Precision: 2,
 		},
@@ -268,7 +271,7 @@ func TestBalanceMonitorService_Update_RequestCreationFailure(t *testing.T) {
 	data := blnkgo.MonitorData{
 		Condition: blnkgo.MonitorCondition{
 			Field:     "balance",
-			Operator:  blnkgo.MonitorConditionOperators("greater_than"),
+			Operator:  blnkgo.OperatorGreaterThan,
 			Value:     1000,
 			Precision: 2,
 		},
@@ -295,7 +298,7 @@ func TestBalanceMonitorService_Update_ServerError(t *testing.T) {
 	data := blnkgo.MonitorData{
 		Condition: blnkgo.MonitorCondition{
 			Field:     "balance",
-			Operator:  blnkgo.MonitorConditionOperators("greater_than"),
+			Operator:  blnkgo.OperatorGreaterThan,
 			Value:     1000,
 			Precision: 2,
 		},
@@ -316,3 +319,265 @@ func TestBalanceMonitorService_Update_ServerError(t *testing.T) {
 	assert.Contains(t, err.Error(), "server error")
 	mockClient.AssertExpectations(t)
 }
+
+func TestBalanceMonitorService_ListByBalance_Success(t *testing.T) {
+	mockClient, svc := setupBalanceMonitorService()
+
+	expected := []blnkgo.MonitorDataResp{{MonitorID: "monitor-1", MonitorData: blnkgo.MonitorData{BalanceID: "balance-123"}}}
+	mockClient.On("NewRequest", "balance-monitors/balances/balance-123", http.MethodGet, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]blnkgo.MonitorDataResp) = expected
+	})
+
+	monitors, httpResp, err := svc.ListByBalance("balance-123")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, httpResp.StatusCode)
+	assert.Equal(t, expected, monitors)
+	mockClient.AssertExpectations(t)
+}
+
+func TestBalanceMonitorService_ListByBalance_EmptyID(t *testing.T) {
+	mockClient, svc := setupBalanceMonitorService()
+
+	monitors, httpResp, err := svc.ListByBalance("")
+	assert.Error(t, err)
+	assert.Nil(t, monitors)
+	assert.Nil(t, httpResp)
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
+
+func TestBalanceMonitorService_Delete_Success(t *testing.T) {
+	mockClient, svc := setupBalanceMonitorService()
+
+	mockClient.On("NewRequest", "balance-monitors/monitor-123", http.MethodDelete, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, nil).Return(&http.Response{StatusCode: http.StatusNoContent}, nil)
+
+	httpResp, err := svc.Delete("monitor-123")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, httpResp.StatusCode)
+	mockClient.AssertExpectations(t)
+}
+
+func TestBalanceMonitorService_Delete_ServerError(t *testing.T) {
+	mockClient, svc := setupBalanceMonitorService()
+
+	mockClient.On("NewRequest", "balance-monitors/monitor-123", http.MethodDelete, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, nil).Return(&http.Response{StatusCode: http.StatusNotFound}, errors.New("monitor not found"))
+
+	httpResp, err := svc.Delete("monitor-123")
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, httpResp.StatusCode)
+}
+
+func TestMonitorCondition_Evaluate(t *testing.T) {
+	balance := &blnkgo.LedgerBalance{
+		Balance:              9950,
+		CreditBalance:        20000,
+		DebitBalance:         10050,
+		InflightDebitBalance: 5000,
+	}
+
+	tests := []struct {
+		name      string
+		condition blnkgo.MonitorCondition
+		expected  bool
+	}{
+		{"below threshold", blnkgo.MonitorCondition{Field: "balance", Operator: blnkgo.OperatorLessThan, Value: 100, Precision: 100}, true},
+		{"not above threshold", blnkgo.MonitorCondition{Field: "balance", Operator: blnkgo.OperatorGreaterThan, Value: 100, Precision: 100}, false},
+		{"equal without precision", blnkgo.MonitorCondition{Field: "credit_balance", Operator: blnkgo.OperatorEqualTo, Value: 20000}, true},
+		{"not equal", blnkgo.MonitorCondition{Field: "debit_balance", Operator: blnkgo.OperatorNotEqualTo, Value: 100, Precision: 100}, true},
+		{"greater or equal", blnkgo.MonitorCondition{Field: "inflight_debit_balance", Operator: blnkgo.OperatorGreaterThanOrEqual, Value: 50, Precision: 100}, true},
+		{"less or equal", blnkgo.MonitorCondition{Field: "inflight_balance", Operator: blnkgo.OperatorLessThanOrEqual, Value: 0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fired, err := tt.condition.Evaluate(balance)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, fired)
+		})
+	}
+}
+
+func TestMonitorCondition_Evaluate_Invalid(t *testing.T) {
+	balance := &blnkgo.LedgerBalance{}
+
+	_, err := blnkgo.MonitorCondition{Field: "available", Operator: blnkgo.OperatorLessThan}.Evaluate(balance)
+	assert.ErrorContains(t, err, "unknown balance field")
+
+	_, err = blnkgo.MonitorCondition{Field: "balance", Operator: "greater_than"}.Evaluate(balance)
+	assert.ErrorContains(t, err, "unknown operator")
+
+	_, err = blnkgo.MonitorCondition{Field: "balance", Operator: blnkgo.OperatorLessThan}.Evaluate(nil)
+	assert.Error(t, err)
+}
+
+func TestBalanceMonitorService_Create_ConditionGroup(t *testing.T) {
+	mockClient, svc := setupBalanceMonitorService()
+
+	data := blnkgo.MonitorData{
+		BalanceID: "balance-123",
+		ConditionGroup: &blnkgo.MonitorConditionGroup{
+			Operator: blnkgo.GroupOperatorAnd,
+			Conditions: []blnkgo.MonitorCondition{
+				{Field: "balance", Operator: blnkgo.OperatorLessThan, Value: 100, Precision: 100},
+				{Field: "inflight_debit_balance", Operator: blnkgo.OperatorGreaterThan, Value: 50, Precision: 100},
+			},
+		},
+	}
+
+	mockClient.On("NewRequest", "balance-monitors", http.MethodPost, data).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusCreated}, nil).Run(func(args mock.Arguments) {
+		resp := args.Get(1).(*blnkgo.MonitorDataResp)
+		*resp = blnkgo.MonitorDataResp{MonitorData: data, MonitorID: "monitor-1"}
+	})
+
+	resp, _, err := svc.Create(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "monitor-1", resp.MonitorID)
+	assert.Equal(t, blnkgo.GroupOperatorAnd, resp.ConditionGroup.Operator)
+	mockClient.AssertExpectations(t)
+}
+
+func TestBalanceMonitorService_Create_ConditionGroupRequestBody(t *testing.T) {
+	var body map[string]interface{}
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		raw, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		body = nil
+		require.NoError(t, json.Unmarshal(raw, &body))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"monitor_id":"monitor-1"}`))
+	})
+
+	_, _, err := client.BalanceMonitor.Create(blnkgo.MonitorData{
+		BalanceID: "balance-123",
+		ConditionGroup: &blnkgo.MonitorConditionGroup{
+			Operator:   blnkgo.GroupOperatorOr,
+			Conditions: []blnkgo.MonitorCondition{{Field: "balance", Operator: blnkgo.OperatorLessThan, Value: 100, Precision: 100}},
+		},
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, body, "condition")
+	assert.Contains(t, body, "condition_group")
+
+	_, _, err = client.BalanceMonitor.Create(blnkgo.MonitorData{
+		BalanceID: "balance-123",
+		Condition: blnkgo.MonitorCondition{Field: "balance", Operator: blnkgo.OperatorLessThan, Value: 100, Precision: 100},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, body, "condition")
+	assert.NotContains(t, body, "condition_group")
+}
+
+func TestMonitorDataResp_MarshalJSON(t *testing.T) {
+	raw, err := json.Marshal(blnkgo.MonitorDataResp{
+		MonitorData: blnkgo.MonitorData{BalanceID: "balance-123", ConditionGroup: &blnkgo.MonitorConditionGroup{Operator: blnkgo.GroupOperatorAnd}},
+		MonitorID:   "monitor-1",
+		CreatedAt:   "2024-01-01T00:00:00Z",
+	})
+	require.NoError(t, err)
+
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(raw, &out))
+	assert.Equal(t, "monitor-1", out["monitor_id"])
+	assert.Equal(t, "2024-01-01T00:00:00Z", out["created_at"])
+	assert.Equal(t, "balance-123", out["balance_id"])
+	assert.NotContains(t, out, "condition")
+}
+
+func TestBalanceMonitorService_Create_ValidationError(t *testing.T) {
+	mockClient, svc := setupBalanceMonitorService()
+
+	data := blnkgo.MonitorData{
+		ConditionGroup: &blnkgo.MonitorConditionGroup{
+			Operator: "XOR",
+			Conditions: []blnkgo.MonitorCondition{
+				{Field: "available_balance", Operator: blnkgo.OperatorLessThan, Value: 100},
+			},
+			Groups: []blnkgo.MonitorConditionGroup{
+				{Operator: blnkgo.GroupOperatorOr},
+			},
+		},
+	}
+
+	resp, httpResp, err := svc.Create(data)
+
+	assert.Nil(t, resp)
+	assert.Nil(t, httpResp)
+	var verr *blnkgo.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.True(t, verr.HasField("balance_id"))
+	assert.True(t, verr.HasField("condition_group.operator"))
+	assert.True(t, verr.HasField("condition_group.conditions[0].field"))
+	assert.True(t, verr.HasField("condition_group.groups[0].conditions"))
+	mockClient.AssertNotCalled(t, "NewRequest", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBalanceMonitorService_Update_ValidationError(t *testing.T) {
+	mockClient, svc := setupBalanceMonitorService()
+
+	data := blnkgo.MonitorData{
+		BalanceID: "balance-123",
+		Condition: blnkgo.MonitorCondition{Field: "balance", Operator: "greater_than"},
+		ConditionGroup: &blnkgo.MonitorConditionGroup{
+			Operator:   blnkgo.GroupOperatorOr,
+			Conditions: []blnkgo.MonitorCondition{{Field: "balance", Operator: blnkgo.OperatorLessThan}},
+		},
+	}
+
+	_, _, err := svc.Update("monitor-123", data)
+
+	var verr *blnkgo.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.True(t, verr.HasField("condition_group"))
+	mockClient.AssertNotCalled(t, "NewRequest", mock.Anything, mock.Anything, mock.Anything)
+
+	_, _, err = svc.Update("monitor-123", blnkgo.MonitorData{BalanceID: "balance-123"})
+	assert.ErrorAs(t, err, &verr)
+	assert.True(t, verr.HasField("condition"))
+}
+
+func TestMonitorConditionGroup_Evaluate(t *testing.T) {
+	balance := &blnkgo.LedgerBalance{
+		Balance:              9950,
+		InflightDebitBalance: 5000,
+	}
+	lowBalance := blnkgo.MonitorCondition{Field: "balance", Operator: blnkgo.OperatorLessThan, Value: 100, Precision: 100}
+	highInflight := blnkgo.MonitorCondition{Field: "inflight_debit_balance", Operator: blnkgo.OperatorGreaterThan, Value: 40, Precision: 100}
+	highBalance := blnkgo.MonitorCondition{Field: "balance", Operator: blnkgo.OperatorGreaterThan, Value: 1000, Precision: 100}
+
+	tests := []struct {
+		name     string
+		group    blnkgo.MonitorConditionGroup
+		expected bool
+	}{
+		{"and holds", blnkgo.MonitorConditionGroup{Operator: blnkgo.GroupOperatorAnd, Conditions: []blnkgo.MonitorCondition{lowBalance, highInflight}}, true},
+		{"and fails", blnkgo.MonitorConditionGroup{Operator: blnkgo.GroupOperatorAnd, Conditions: []blnkgo.MonitorCondition{lowBalance, highInflight, highBalance}}, false},
+		{"or holds", blnkgo.MonitorConditionGroup{Operator: blnkgo.GroupOperatorOr, Conditions: []blnkgo.MonitorCondition{highBalance, highInflight}}, true},
+		{"or fails", blnkgo.MonitorConditionGroup{Operator: blnkgo.GroupOperatorOr, Conditions: []blnkgo.MonitorCondition{highBalance}}, false},
+		{"nested", blnkgo.MonitorConditionGroup{
+			Operator:   blnkgo.GroupOperatorAnd,
+			Conditions: []blnkgo.MonitorCondition{lowBalance},
+			Groups: []blnkgo.MonitorConditionGroup{
+				{Operator: blnkgo.GroupOperatorOr, Conditions: []blnkgo.MonitorCondition{highBalance, highInflight}},
+			},
+		}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fired, err := tt.group.Evaluate(balance)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, fired)
+		})
+	}
+
+	_, err := blnkgo.MonitorConditionGroup{Operator: blnkgo.GroupOperatorAnd}.Evaluate(balance)
+	assert.Error(t, err)
+
+	fired, err := blnkgo.MonitorData{Condition: lowBalance}.Evaluate(balance)
+	assert.NoError(t, err)
+	assert.True(t, fired)
+}
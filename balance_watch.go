@@ -0,0 +1,206 @@
+package blnkgo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	watchMinInterval = time.Second
+	watchMaxInterval = 30 * time.Second
+	watchConcurrency = 10
+)
+
+// BalanceWatchOptions tunes Watch. Each balance is polled every MinInterval while it keeps
+// changing, the interval doubles up to MaxInterval while it stays the same, and at most
+// Concurrency balances are fetched at once in every polling round.
+type BalanceWatchOptions struct {
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	Concurrency int
+}
+
+// BalanceDelta holds how much each field of a balance moved between two polls.
+type BalanceDelta struct {
+	Balance               int `json:"balance"`
+	CreditBalance         int `json:"credit_balance"`
+	DebitBalance          int `json:"debit_balance"`
+	InflightBalance       int `json:"inflight_balance"`
+	InflightCreditBalance int `json:"inflight_credit_balance"`
+	InflightDebitBalance  int `json:"inflight_debit_balance"`
+}
+
+func (d BalanceDelta) IsZero() bool {
+	return d == BalanceDelta{}
+}
+
+// BalanceChangeEvent is emitted by Watch when the version of a balance moves. When a poll
+// fails, Err is set and Previous holds the last balance seen, if any.
+type BalanceChangeEvent struct {
+	BalanceID  string
+	Previous   *LedgerBalance
+	Current    *LedgerBalance
+	Delta      BalanceDelta
+	DetectedAt time.Time
+	Err        error
+}
+
+type watchedBalance struct {
+	id       string
+	last     *LedgerBalance
+	interval time.Duration
+	next     time.Time
+}
+
+type balancePoll struct {
+	balance *LedgerBalance
+	err     error
+}
+
+// Watch polls the given balances and emits an event on the returned channel every time one
+// of them changes. The first poll of each balance only records its state. The channel is
+// closed once ctx ends.
+func (s *LedgerBalanceService) Watch(ctx context.Context, balanceIDs ...string) (<-chan BalanceChangeEvent, error) {
+	return s.WatchWithOptions(ctx, BalanceWatchOptions{}, balanceIDs...)
+}
+
+// WatchWithOptions is Watch with custom polling intervals and concurrency.
+func (s *LedgerBalanceService) WatchWithOptions(ctx context.Context, opts BalanceWatchOptions, balanceIDs ...string) (<-chan BalanceChangeEvent, error) {
+	if len(balanceIDs) == 0 {
+		return nil, fmt.Errorf("at least one balanceID is required")
+	}
+	if opts.MinInterval <= 0 {
+		opts.MinInterval = watchMinInterval
+	}
+	if opts.MaxInterval < opts.MinInterval {
+		opts.MaxInterval = watchMaxInterval
+		if opts.MaxInterval < opts.MinInterval {
+			opts.MaxInterval = opts.MinInterval
+		}
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = watchConcurrency
+	}
+
+	seen := make(map[string]bool)
+	var balances []*watchedBalance
+	for _, id := range balanceIDs {
+		if id == "" {
+			return nil, fmt.Errorf("balanceID can not be empty")
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		balances = append(balances, &watchedBalance{id: id, interval: opts.MinInterval})
+	}
+
+	events := make(chan BalanceChangeEvent, len(balances))
+	go s.watch(ctx, opts, balances, events)
+	return events, nil
+}
+
+func (s *LedgerBalanceService) watch(ctx context.Context, opts BalanceWatchOptions, balances []*watchedBalance, events chan<- BalanceChangeEvent) {
+	defer close(events)
+
+	for {
+		now := time.Now()
+		var due []*watchedBalance
+		for _, b := range balances {
+			if !now.Before(b.next) {
+				due = append(due, b)
+			}
+		}
+
+		polls := s.pollBalances(due, opts.Concurrency)
+		for i, b := range due {
+			event, changed := b.update(polls[i], opts)
+			if !changed {
+				continue
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		//sleep until the next balance is due
+		next := balances[0].next
+		for _, b := range balances[1:] {
+			if b.next.Before(next) {
+				next = b.next
+			}
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// pollBalances fetches the due balances with at most concurrency requests in flight
+func (s *LedgerBalanceService) pollBalances(due []*watchedBalance, concurrency int) []balancePoll {
+	polls := make([]balancePoll, len(due))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, b := range due {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			balance, _, err := s.Get(id)
+			polls[i] = balancePoll{balance: balance, err: err}
+		}(i, b.id)
+	}
+	wg.Wait()
+	return polls
+}
+
+// update records a poll result, adapts the polling interval and reports whether an event is due
+func (b *watchedBalance) update(poll balancePoll, opts BalanceWatchOptions) (BalanceChangeEvent, bool) {
+	event := BalanceChangeEvent{BalanceID: b.id, Previous: b.last, DetectedAt: time.Now()}
+
+	switch {
+	case poll.err != nil:
+		event.Err = poll.err
+		b.backOff(opts)
+		return event, true
+	case b.last == nil:
+		b.last = poll.balance
+		b.backOff(opts)
+		return event, false
+	case poll.balance.Version == b.last.Version:
+		b.backOff(opts)
+		return event, false
+	}
+
+	event.Current = poll.balance
+	event.Delta = BalanceDelta{
+		Balance:               poll.balance.Balance - b.last.Balance,
+		CreditBalance:         poll.balance.CreditBalance - b.last.CreditBalance,
+		DebitBalance:          poll.balance.DebitBalance - b.last.DebitBalance,
+		InflightBalance:       poll.balance.InflightBalance - b.last.InflightBalance,
+		InflightCreditBalance: poll.balance.InflightCreditBalance - b.last.InflightCreditBalance,
+		InflightDebitBalance:  poll.balance.InflightDebitBalance - b.last.InflightDebitBalance,
+	}
+	b.last = poll.balance
+	//a balance that just changed is likely to change again soon
+	b.interval = opts.MinInterval
+	b.next = time.Now().Add(b.interval)
+	return event, true
+}
+
+func (b *watchedBalance) backOff(opts BalanceWatchOptions) {
+	b.next = time.Now().Add(b.interval)
+	b.interval *= 2
+	if b.interval > opts.MaxInterval {
+		b.interval = opts.MaxInterval
+	}
+}
@@ -0,0 +1,101 @@
+package blnkgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedgerBalanceService_Watch(t *testing.T) {
+	var mu sync.Mutex
+	polls := map[string]int{}
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/balances/")
+		mu.Lock()
+		polls[id]++
+		n := polls[id]
+		mu.Unlock()
+
+		if id == "bal-missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"balance not found"}`))
+			return
+		}
+		balance := blnkgo.LedgerBalance{BalanceID: id, Version: 1, Balance: 1000, CreditBalance: 1000}
+		if n > 1 {
+			//the second poll sees a debit of 250
+			balance.Version = 2
+			balance.Balance = 750
+			balance.DebitBalance = 250
+		}
+		_ = json.NewEncoder(w).Encode(balance)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, err := client.LedgerBalance.WatchWithOptions(ctx, blnkgo.BalanceWatchOptions{
+		MinInterval: 10 * time.Millisecond,
+		MaxInterval: 20 * time.Millisecond,
+	}, "bal-1", "bal-missing", "bal-1")
+	require.NoError(t, err)
+
+	var change, failure *blnkgo.BalanceChangeEvent
+	timeout := time.After(2 * time.Second)
+	for change == nil || failure == nil {
+		select {
+		case event := <-events:
+			if event.Err != nil {
+				failure = &event
+			} else {
+				change = &event
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for balance events")
+		}
+	}
+
+	assert.Equal(t, "bal-1", change.BalanceID)
+	assert.Equal(t, 1, change.Previous.Version)
+	assert.Equal(t, 2, change.Current.Version)
+	assert.Equal(t, blnkgo.BalanceDelta{Balance: -250, DebitBalance: 250}, change.Delta)
+	assert.Equal(t, "bal-missing", failure.BalanceID)
+	assert.Nil(t, failure.Previous)
+
+	cancel()
+	for range events {
+		//drain until the watcher closes the channel
+	}
+}
+
+func TestLedgerBalanceService_Watch_NoChangeNoEvent(t *testing.T) {
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(blnkgo.LedgerBalance{BalanceID: "bal-1", Version: 3})
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	events, err := client.LedgerBalance.WatchWithOptions(ctx, blnkgo.BalanceWatchOptions{MinInterval: 5 * time.Millisecond}, "bal-1")
+	require.NoError(t, err)
+
+	for event := range events {
+		t.Fatalf("unexpected event %+v", event)
+	}
+}
+
+func TestLedgerBalanceService_Watch_Invalid(t *testing.T) {
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	_, err := client.LedgerBalance.Watch(context.Background())
+	assert.Error(t, err)
+
+	_, err = client.LedgerBalance.Watch(context.Background(), "bal-1", "")
+	assert.Error(t, err)
+}
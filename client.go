@@ -11,12 +11,15 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/go-querystring/query"
 )
 
 type Client struct {
+	// ApiKey is used when no CredentialsProvider has been configured
 	ApiKey         *string
 	BaseURL        *url.URL
 	options        Options
@@ -28,6 +31,19 @@ type Client struct {
 	Identity       *IdentityService
 	Search         *SearchService
 	Reconciliation *ReconciliationService
+	Hook           *HookService
+	APIKey         *APIKeyService
+
+	credentials  CredentialsProvider
+	credsMu      sync.RWMutex
+	signer       *RequestSigner
+	encryptor    *MetadataEncryptor
+	limiter      *rateLimiter
+	capabilities capabilityCache
+	// configErr holds an error from building the client, requests fail with it
+	configErr error
+	// baseURLErr is set while the client has no base url
+	baseURLErr error
 }
 
 // create a client interface
@@ -43,28 +59,30 @@ type service struct {
 
 type Options struct {
 	RetryCount int
+	RetryWait  time.Duration
 	Timeout    time.Duration
 	Logger     Logger
+	TLS        *TLSOptions
+	RateLimit  *RateLimit
+	// IdentityValidators run on every identity create and update
+	IdentityValidators []IdentityValidator
 }
 
 func DefaultOptions() Options {
 	return Options{
 		RetryCount: 1,
+		RetryWait:  time.Second * 2,
 		Timeout:    time.Second * 10,
 		Logger:     NewDefaultLogger(),
 	}
 }
 
-func NewClient(baseURL *url.URL, apiKey *string, opts ...ClientOption) *Client {
-	//if base url is nil or empty, return error
-	if baseURL == nil || baseURL.String() == "" {
-		panic(errors.New("base url is required"))
-	}
+// ErrMissingBaseURL is returned by every request of a client built without a base url
+var ErrMissingBaseURL = errors.New("base url is required")
 
-	//check if base url ends with a "/", if it doesnt append it
-	if baseURL.String()[len(baseURL.String())-1:] != "/" {
-		baseURL.Path += "/"
-	}
+func NewClient(baseURL *url.URL, apiKey *string, opts ...ClientOption) *Client {
+	//a missing base url is reported by every request until SetBaseURL fixes it
+	baseURL, baseURLErr := normalizeBaseURL(baseURL)
 
 	//set default options if not provided
 	client := &Client{
@@ -72,6 +90,8 @@ func NewClient(baseURL *url.URL, apiKey *string, opts ...ClientOption) *Client {
 		BaseURL: baseURL,
 		options: DefaultOptions(),
 		client:  &http.Client{Timeout: 10 * time.Second},
+
+		baseURLErr: baseURLErr,
 	}
 
 	//apply options
@@ -86,20 +106,48 @@ func NewClient(baseURL *url.URL, apiKey *string, opts ...ClientOption) *Client {
 		}
 	}
 
+	//build the tls transport, a failure is reported by every request
+	if client.options.TLS != nil {
+		client.configErr = client.applyTLS(client.options.TLS)
+	}
+	if client.options.RateLimit != nil {
+		client.limiter = newRateLimiter(*client.options.RateLimit)
+	}
+	client.attachCredentialsLogger()
+
 	//initialize services
 	client.Ledger = &LedgerService{client: client}
 	client.LedgerBalance = &LedgerBalanceService{client: client}
 	client.Transaction = &TransactionService{client: client}
 	client.BalanceMonitor = &BalanceMonitorService{client: client}
-	client.Identity = &IdentityService{client: client}
+	client.Identity = NewIdentityService(client, client.options.IdentityValidators...)
 	client.Search = &SearchService{client: client}
 	client.Reconciliation = &ReconciliationService{client: client}
+	client.Hook = &HookService{client: client}
+	client.APIKey = &APIKeyService{client: client}
 
 	return client
 }
 
+// SetBaseURL replaces the base url, a missing url fails every request with ErrMissingBaseURL
 func (c *Client) SetBaseURL(baseURL *url.URL) {
-	c.BaseURL = baseURL
+	c.BaseURL, c.baseURLErr = normalizeBaseURL(baseURL)
+}
+
+// normalizeBaseURL makes sure baseURL ends with a "/" so endpoints can be appended to it
+func normalizeBaseURL(baseURL *url.URL) (*url.URL, error) {
+	if baseURL == nil || baseURL.String() == "" {
+		return &url.URL{}, ErrMissingBaseURL
+	}
+	if !strings.HasSuffix(baseURL.String(), "/") {
+		baseURL.Path += "/"
+	}
+	return baseURL, nil
+}
+
+// buildErr reports why the client can not send requests, if it can not
+func (c *Client) buildErr() error {
+	return errors.Join(c.baseURLErr, c.configErr)
 }
 
 func (c *Client) NewRequest(endpoint, method string, opt interface{}) (*http.Request, error) {
@@ -108,6 +156,9 @@ func (c *Client) NewRequest(endpoint, method string, opt interface{}) (*http.Req
 	//method is the HTTP method
 	//opt is the request body
 	//returns the request and an error if any
+	if err := c.buildErr(); err != nil {
+		return nil, err
+	}
 
 	u, err := url.Parse(c.BaseURL.String() + endpoint)
 	if err != nil {
@@ -124,24 +175,42 @@ func (c *Client) NewRequest(endpoint, method string, opt interface{}) (*http.Req
 		u.RawQuery = q.Encode()
 	}
 
-	var bodyBuf io.ReadWriter
+	var bodyBuf *bytes.Buffer
+	var body io.Reader
 
 	if method != http.MethodGet && opt != nil {
+		//encrypt the configured meta data keys before the body is serialized and signed
+		if c.encryptor != nil {
+			opt, err = c.encryptor.encryptBody(opt)
+			if err != nil {
+				return nil, err
+			}
+		}
 		bodyBuf = new(bytes.Buffer)
 		err := json.NewEncoder(bodyBuf).Encode(opt)
 		if err != nil {
 			return nil, err
 		}
+		body = bodyBuf
 	}
 
-	req, err := http.NewRequest(method, u.String(), bodyBuf)
+	req, err := http.NewRequest(method, u.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
-	//if c has api key, add it to the header
-	if c.ApiKey != nil {
-		req.Header.Add("X-Blnk-Key", *c.ApiKey)
+	//sign mutating requests when a signer is configured
+	if c.signer != nil && shouldSign(method) {
+		var payload []byte
+		if bodyBuf != nil {
+			payload = bodyBuf.Bytes()
+		}
+		c.signer.Sign(req, payload)
+	}
+
+	//add the api key and bearer token from the current credentials
+	if err := c.setAuthHeaders(req); err != nil {
+		return nil, err
 	}
 	req.Header.Add("Content-Type", "application/json")
 
@@ -156,10 +225,13 @@ func (c *Client) CallWithRetry(req *http.Request, resBody interface{}) (*http.Re
 	var err error
 
 	for i := 0; i < retryCount; i++ {
+		if c.limiter != nil {
+			c.limiter.wait()
+		}
 		resp, err = c.client.Do(req)
 		if err != nil {
 			c.options.Logger.Info(err.Error())
-			time.Sleep(time.Second * 2)
+			time.Sleep(c.options.RetryWait)
 			continue
 		}
 
@@ -167,7 +239,7 @@ func (c *Client) CallWithRetry(req *http.Request, resBody interface{}) (*http.Re
 		if resp.StatusCode >= 500 {
 			logString := fmt.Sprintf("Request failed with status code %v and Status %v", resp.StatusCode, resp.Status)
 			c.options.Logger.Error(logString)
-			time.Sleep(time.Second * 2)
+			time.Sleep(c.options.RetryWait)
 			continue
 		}
 
@@ -193,6 +265,29 @@ func (c *Client) DecodeResponse(resp *http.Response, v interface{}) error {
 		return err
 	}
 
+	//callers that expect no body, such as deletes, pass a nil value
+	if v == nil {
+		return nil
+	}
+
+	//callers that expect a raw body, such as file downloads, pass an io.Writer
+	if w, ok := v.(io.Writer); ok {
+		_, err = io.Copy(w, resp.Body)
+		return err
+	}
+
+	if c.encryptor != nil {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return err
+		}
+		data, err = c.encryptor.decryptBody(data)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(data, v)
+	}
+
 	err = json.NewDecoder(resp.Body).Decode(v)
 	if err != nil {
 		return err
@@ -202,6 +297,9 @@ func (c *Client) DecodeResponse(resp *http.Response, v interface{}) error {
 }
 
 func (c *Client) NewFileUploadRequest(endpoint string, fileParam string, file interface{}, fileName string, fields map[string]string) (*http.Request, error) {
+	if err := c.buildErr(); err != nil {
+		return nil, err
+	}
 	// Prepare multipart form data
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -257,9 +355,11 @@ func (c *Client) NewFileUploadRequest(endpoint string, fileParam string, file in
 		return nil, err
 	}
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	//print out the file type
-	if c.ApiKey != nil {
-		req.Header.Add("X-Blnk-Key", *c.ApiKey)
+	if c.signer != nil {
+		c.signer.Sign(req, body.Bytes())
+	}
+	if err := c.setAuthHeaders(req); err != nil {
+		return nil, err
 	}
 
 	return req, nil
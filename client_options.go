@@ -1,6 +1,9 @@
 package blnkgo
 
-import "time"
+import (
+	"crypto/x509"
+	"time"
+)
 
 type ClientOption func(*Client)
 
@@ -16,8 +19,111 @@ func WithRetry(count int) ClientOption {
 	}
 }
 
+// WithRetryWait sets how long to wait between retries
+func WithRetryWait(wait time.Duration) ClientOption {
+	return func(c *Client) {
+		c.options.RetryWait = wait
+	}
+}
+
+// WithRateLimit limits outgoing requests to requestsPerSecond with bursts of up to burst requests
+func WithRateLimit(requestsPerSecond float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.options.RateLimit = &RateLimit{RequestsPerSecond: requestsPerSecond, Burst: burst}
+	}
+}
+
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
 		c.options.Timeout = timeout
 	}
 }
+
+// WithCredentialsProvider sets the provider consulted for credentials on every request
+func WithCredentialsProvider(provider CredentialsProvider) ClientOption {
+	return func(c *Client) {
+		c.credentials = provider
+	}
+}
+
+// WithRequestSigner signs every POST, PUT, PATCH and DELETE request, including file uploads
+func WithRequestSigner(signer *RequestSigner) ClientOption {
+	return func(c *Client) {
+		c.signer = signer
+	}
+}
+
+// WithMetadataEncryptor encrypts the encryptor's meta data keys in request bodies and
+// decrypts them in responses
+func WithMetadataEncryptor(encryptor *MetadataEncryptor) ClientOption {
+	return func(c *Client) {
+		c.encryptor = encryptor
+	}
+}
+
+// WithIdentityValidators adds validators run on every identity create and update
+func WithIdentityValidators(validators ...IdentityValidator) ClientOption {
+	return func(c *Client) {
+		c.options.IdentityValidators = append(c.options.IdentityValidators, validators...)
+	}
+}
+
+// WithTLS replaces the TLS configuration used to reach the server
+func WithTLS(tlsOptions TLSOptions) ClientOption {
+	return func(c *Client) {
+		c.options.TLS = &tlsOptions
+	}
+}
+
+// WithClientCertificate presents the certificate in certFile and keyFile to the server
+func WithClientCertificate(certFile, keyFile string) ClientOption {
+	return func(c *Client) {
+		tlsOptions := c.tlsOptions()
+		tlsOptions.CertFile = certFile
+		tlsOptions.KeyFile = keyFile
+	}
+}
+
+// WithRootCAs verifies the server against pool instead of the system roots
+func WithRootCAs(pool *x509.CertPool) ClientOption {
+	return func(c *Client) {
+		c.tlsOptions().RootCAs = pool
+	}
+}
+
+// WithRootCAFile verifies the server against the PEM certificates in caFile
+func WithRootCAFile(caFile string) ClientOption {
+	return func(c *Client) {
+		c.tlsOptions().CAFile = caFile
+	}
+}
+
+// WithMinTLSVersion sets the minimum TLS version, for example tls.VersionTLS13
+func WithMinTLSVersion(version uint16) ClientOption {
+	return func(c *Client) {
+		c.tlsOptions().MinVersion = version
+	}
+}
+
+// WithCertificateReload re-reads the client certificate from disk at most once per interval
+func WithCertificateReload(interval time.Duration) ClientOption {
+	return func(c *Client) {
+		c.tlsOptions().ReloadInterval = interval
+	}
+}
+
+// WithCertificateMaxStale sets how long the last good client certificate is used while the
+// files can not be reloaded, negative keeps using it indefinitely
+func WithCertificateMaxStale(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.tlsOptions().MaxStale = d
+	}
+}
+
+// tlsOptions returns the TLS options, creating them on first use
+func (c *Client) tlsOptions() *TLSOptions {
+	if c.options.TLS == nil {
+		c.options.TLS = &TLSOptions{}
+	}
+	return c.options.TLS
+}
@@ -0,0 +1,277 @@
+package blnkgo
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment variables read by NewClientFromEnv, BLNK_API_KEY and BLNK_BEARER_TOKEN are
+// shared with EnvCredentials.
+const (
+	EnvBaseURL           = "BLNK_BASE_URL"
+	EnvAPIKeyFile        = "BLNK_API_KEY_FILE"
+	EnvTimeout           = "BLNK_TIMEOUT"
+	EnvRetryCount        = "BLNK_RETRY_COUNT"
+	EnvRetryWait         = "BLNK_RETRY_WAIT"
+	EnvRateLimitRPS      = "BLNK_RATE_LIMIT_RPS"
+	EnvRateLimitBurst    = "BLNK_RATE_LIMIT_BURST"
+	EnvTLSCertFile       = "BLNK_TLS_CERT_FILE"
+	EnvTLSKeyFile        = "BLNK_TLS_KEY_FILE"
+	EnvTLSCAFile         = "BLNK_TLS_CA_FILE"
+	EnvTLSMinVersion     = "BLNK_TLS_MIN_VERSION"
+	EnvTLSReloadInterval = "BLNK_TLS_RELOAD_INTERVAL"
+	EnvTLSServerName     = "BLNK_TLS_SERVER_NAME"
+)
+
+// credentialsReloadInterval is how often an api key file is checked for changes
+const credentialsReloadInterval = 30 * time.Second
+
+// Duration is a time.Duration read from strings such as "10s" in config files
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"10s\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	parsed, err := time.ParseDuration(node.Value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// RetryConfig is the retry policy section of Config
+type RetryConfig struct {
+	Count int      `json:"count" yaml:"count"`
+	Wait  Duration `json:"wait" yaml:"wait"`
+}
+
+// TLSConfig is the TLS section of Config, MinVersion takes "1.2" or "1.3"
+type TLSConfig struct {
+	CertFile       string   `json:"cert_file" yaml:"cert_file"`
+	KeyFile        string   `json:"key_file" yaml:"key_file"`
+	CAFile         string   `json:"ca_file" yaml:"ca_file"`
+	MinVersion     string   `json:"min_version" yaml:"min_version"`
+	ReloadInterval Duration `json:"reload_interval" yaml:"reload_interval"`
+	ServerName     string   `json:"server_name" yaml:"server_name"`
+}
+
+// Config describes a client in a JSON or YAML file or in environment variables.
+type Config struct {
+	BaseURL     string      `json:"base_url" yaml:"base_url"`
+	APIKey      string      `json:"api_key" yaml:"api_key"`
+	APIKeyFile  string      `json:"api_key_file" yaml:"api_key_file"`
+	BearerToken string      `json:"bearer_token" yaml:"bearer_token"`
+	Timeout     Duration    `json:"timeout" yaml:"timeout"`
+	Retry       RetryConfig `json:"retry" yaml:"retry"`
+	RateLimit   RateLimit   `json:"rate_limit" yaml:"rate_limit"`
+	TLS         TLSConfig   `json:"tls" yaml:"tls"`
+}
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// Validate returns a *ValidationError listing every invalid setting
+func (c Config) Validate() error {
+	verr := &ValidationError{}
+	if c.BaseURL == "" {
+		verr.Add("base_url", ValidationCodeRequired, "base url is required")
+	} else if u, err := url.Parse(c.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		verr.Add("base_url", ValidationCodeInvalid, "base url must be an absolute url such as http://localhost:5001/")
+	}
+	if c.APIKey != "" && c.APIKeyFile != "" {
+		verr.Add("api_key_file", ValidationCodeConflict, "you can not use both api_key and api_key_file")
+	}
+	if c.Timeout < 0 {
+		verr.Add("timeout", ValidationCodeNegative, "timeout can not be negative")
+	}
+	if c.Retry.Count < 0 {
+		verr.Add("retry.count", ValidationCodeNegative, "retry count can not be negative")
+	}
+	if c.Retry.Wait < 0 {
+		verr.Add("retry.wait", ValidationCodeNegative, "retry wait can not be negative")
+	}
+	if c.RateLimit.RequestsPerSecond < 0 {
+		verr.Add("rate_limit.requests_per_second", ValidationCodeNegative, "requests per second can not be negative")
+	}
+	if c.RateLimit.Burst < 0 {
+		verr.Add("rate_limit.burst", ValidationCodeNegative, "burst can not be negative")
+	}
+	if (c.TLS.CertFile == "") != (c.TLS.KeyFile == "") {
+		verr.Add("tls.key_file", ValidationCodeRequired, "cert_file and key_file must be set together")
+	}
+	if _, ok := tlsVersions[c.TLS.MinVersion]; c.TLS.MinVersion != "" && !ok {
+		verr.Add("tls.min_version", ValidationCodeInvalid, "min_version must be 1.2 or 1.3")
+	}
+	return verr.ErrOrNil()
+}
+
+// NewClient validates c and builds a client from it
+func (c Config) NewClient(opts ...ClientOption) (*Client, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	baseURL, _ := url.Parse(c.BaseURL)
+	var configOpts []ClientOption
+
+	switch {
+	case c.APIKeyFile != "":
+		provider, err := NewFileCredentials(c.APIKeyFile, credentialsReloadInterval)
+		if err != nil {
+			return nil, err
+		}
+		configOpts = append(configOpts, WithCredentialsProvider(provider))
+	case c.APIKey != "" || c.BearerToken != "":
+		configOpts = append(configOpts, WithCredentialsProvider(StaticCredentials{APIKey: c.APIKey, BearerToken: c.BearerToken}))
+	}
+	if c.Timeout > 0 {
+		configOpts = append(configOpts, WithTimeout(time.Duration(c.Timeout)))
+	}
+	if c.Retry.Count > 0 {
+		configOpts = append(configOpts, WithRetry(c.Retry.Count))
+	}
+	if c.Retry.Wait > 0 {
+		configOpts = append(configOpts, WithRetryWait(time.Duration(c.Retry.Wait)))
+	}
+	if c.RateLimit.RequestsPerSecond > 0 {
+		configOpts = append(configOpts, WithRateLimit(c.RateLimit.RequestsPerSecond, c.RateLimit.Burst))
+	}
+	if c.TLS != (TLSConfig{}) {
+		configOpts = append(configOpts, WithTLS(TLSOptions{
+			CertFile:       c.TLS.CertFile,
+			KeyFile:        c.TLS.KeyFile,
+			CAFile:         c.TLS.CAFile,
+			MinVersion:     tlsVersions[c.TLS.MinVersion],
+			ReloadInterval: time.Duration(c.TLS.ReloadInterval),
+			ServerName:     c.TLS.ServerName,
+		}))
+	}
+
+	//options passed by the caller override the ones from the config
+	client := NewClient(baseURL, nil, append(configOpts, opts...)...)
+	if err := client.buildErr(); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// LoadConfigFromEnv reads a Config from BLNK_* environment variables
+func LoadConfigFromEnv() (Config, error) {
+	verr := &ValidationError{}
+	cfg := Config{
+		BaseURL:     os.Getenv(EnvBaseURL),
+		APIKey:      os.Getenv(EnvAPIKey),
+		APIKeyFile:  os.Getenv(EnvAPIKeyFile),
+		BearerToken: os.Getenv(EnvBearerToken),
+		TLS: TLSConfig{
+			CertFile:   os.Getenv(EnvTLSCertFile),
+			KeyFile:    os.Getenv(EnvTLSKeyFile),
+			CAFile:     os.Getenv(EnvTLSCAFile),
+			MinVersion: os.Getenv(EnvTLSMinVersion),
+			ServerName: os.Getenv(EnvTLSServerName),
+		},
+	}
+
+	envDuration(verr, EnvTimeout, &cfg.Timeout)
+	envDuration(verr, EnvRetryWait, &cfg.Retry.Wait)
+	envDuration(verr, EnvTLSReloadInterval, &cfg.TLS.ReloadInterval)
+	if v := os.Getenv(EnvRetryCount); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			verr.Add(EnvRetryCount, ValidationCodeInvalid, "must be an integer")
+		}
+		cfg.Retry.Count = n
+	}
+	if v := os.Getenv(EnvRateLimitRPS); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			verr.Add(EnvRateLimitRPS, ValidationCodeInvalid, "must be a number")
+		}
+		cfg.RateLimit.RequestsPerSecond = n
+	}
+	if v := os.Getenv(EnvRateLimitBurst); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			verr.Add(EnvRateLimitBurst, ValidationCodeInvalid, "must be an integer")
+		}
+		cfg.RateLimit.Burst = n
+	}
+
+	return cfg, verr.ErrOrNil()
+}
+
+// LoadConfigFile reads a Config from a .json, .yaml or .yml file
+func LoadConfigFile(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		err = json.Unmarshal(data, &cfg)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &cfg)
+	default:
+		return cfg, fmt.Errorf("unsupported config file type %q, use .json, .yaml or .yml", filepath.Ext(path))
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// NewClientFromEnv builds a client from BLNK_* environment variables
+func NewClientFromEnv(opts ...ClientOption) (*Client, error) {
+	cfg, err := LoadConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NewClient(opts...)
+}
+
+// NewClientFromConfig builds a client from a JSON or YAML config file
+func NewClientFromConfig(path string, opts ...ClientOption) (*Client, error) {
+	cfg, err := LoadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NewClient(opts...)
+}
+
+func envDuration(verr *ValidationError, name string, d *Duration) {
+	v := os.Getenv(name)
+	if v == "" {
+		return
+	}
+	parsed, err := time.ParseDuration(v)
+	if err != nil {
+		verr.Add(name, ValidationCodeInvalid, "must be a duration such as 10s")
+		return
+	}
+	*d = Duration(parsed)
+}
@@ -0,0 +1,151 @@
+package blnkgo_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClientFromConfig_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blnk.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+base_url: http://blnk.internal:5001
+api_key: yaml-key
+timeout: 3s
+retry:
+  count: 4
+  wait: 100ms
+rate_limit:
+  requests_per_second: 50
+  burst: 10
+`), 0o600))
+
+	client, err := blnkgo.NewClientFromConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "http://blnk.internal:5001/", client.BaseURL.String())
+
+	req, err := client.NewRequest("ledgers", http.MethodGet, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "yaml-key", req.Header.Get("X-Blnk-Key"))
+}
+
+func TestLoadConfigFile_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blnk.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"base_url": "https://blnk.example.com",
+		"timeout": "15s",
+		"retry": {"count": 2, "wait": "1s"},
+		"tls": {"min_version": "1.3", "reload_interval": "1m"}
+	}`), 0o600))
+
+	cfg, err := blnkgo.LoadConfigFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, blnkgo.Duration(15*time.Second), cfg.Timeout)
+	assert.Equal(t, 2, cfg.Retry.Count)
+	assert.Equal(t, "1.3", cfg.TLS.MinVersion)
+	assert.Equal(t, blnkgo.Duration(time.Minute), cfg.TLS.ReloadInterval)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoadConfigFile_UnsupportedExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blnk.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`base_url = "x"`), 0o600))
+
+	_, err := blnkgo.LoadConfigFile(path)
+	assert.ErrorContains(t, err, "unsupported config file type")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := blnkgo.Config{
+		APIKey:     "key",
+		APIKeyFile: "/etc/blnk/key",
+		Retry:      blnkgo.RetryConfig{Count: -1},
+		TLS:        blnkgo.TLSConfig{CertFile: "client.pem", MinVersion: "1.0"},
+	}
+
+	var verr *blnkgo.ValidationError
+	require.True(t, errors.As(cfg.Validate(), &verr))
+	for _, field := range []string{"base_url", "api_key_file", "retry.count", "tls.key_file", "tls.min_version"} {
+		assert.True(t, verr.HasField(field), field)
+	}
+}
+
+func TestNewClientFromEnv(t *testing.T) {
+	t.Setenv(blnkgo.EnvBaseURL, "http://localhost:5001")
+	t.Setenv(blnkgo.EnvAPIKey, "env-key")
+	t.Setenv(blnkgo.EnvBearerToken, "env-token")
+	t.Setenv(blnkgo.EnvTimeout, "2s")
+	t.Setenv(blnkgo.EnvRetryCount, "3")
+
+	client, err := blnkgo.NewClientFromEnv()
+	require.NoError(t, err)
+
+	req, err := client.NewRequest("ledgers", http.MethodGet, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "env-key", req.Header.Get("X-Blnk-Key"))
+	assert.Equal(t, "Bearer env-token", req.Header.Get("Authorization"))
+}
+
+func TestNewClientFromEnv_MissingBaseURLReturnsError(t *testing.T) {
+	t.Setenv(blnkgo.EnvBaseURL, "")
+
+	client, err := blnkgo.NewClientFromEnv()
+	assert.Nil(t, client)
+
+	var verr *blnkgo.ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.True(t, verr.HasField("base_url"))
+}
+
+func TestLoadConfigFromEnv_InvalidValues(t *testing.T) {
+	t.Setenv(blnkgo.EnvTimeout, "soon")
+	t.Setenv(blnkgo.EnvRetryCount, "many")
+
+	_, err := blnkgo.LoadConfigFromEnv()
+
+	var verr *blnkgo.ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.True(t, verr.HasField(blnkgo.EnvTimeout))
+	assert.True(t, verr.HasField(blnkgo.EnvRetryCount))
+}
+
+func TestNewClientFromEnv_InvalidBaseURL(t *testing.T) {
+	t.Setenv(blnkgo.EnvBaseURL, "localhost")
+
+	client, err := blnkgo.NewClientFromEnv()
+	assert.Nil(t, client)
+
+	var verr *blnkgo.ValidationError
+	require.True(t, errors.As(err, &verr))
+	assert.True(t, verr.HasField("base_url"))
+}
+
+func TestNewClient_MissingBaseURLFailsRequests(t *testing.T) {
+	client := blnkgo.NewClient(nil, nil)
+
+	_, err := client.NewRequest("ledgers", http.MethodGet, nil)
+	assert.ErrorIs(t, err, blnkgo.ErrMissingBaseURL)
+
+	_, _, err = client.Ledger.Get("ldg-1")
+	assert.ErrorIs(t, err, blnkgo.ErrMissingBaseURL)
+
+	//a later base url makes the client usable
+	baseURL, err := url.Parse("http://localhost:5001/v1")
+	require.NoError(t, err)
+	client.SetBaseURL(baseURL)
+	req, err := client.NewRequest("ledgers", http.MethodGet, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:5001/v1/ledgers", req.URL.String())
+
+	client.SetBaseURL(nil)
+	_, err = client.NewRequest("ledgers", http.MethodGet, nil)
+	assert.ErrorIs(t, err, blnkgo.ErrMissingBaseURL)
+}
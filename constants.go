@@ -93,12 +93,21 @@ const (
 	OperatorLessThanOrEqual    MonitorConditionOperators = "<="
 )
 
+// MonitorGroupOperator combines the conditions of a MonitorConditionGroup.
+type MonitorGroupOperator string
+
+const (
+	GroupOperatorAnd MonitorGroupOperator = "AND"
+	GroupOperatorOr  MonitorGroupOperator = "OR"
+)
+
 type ResourceType string
 
 const (
 	Ledgers      ResourceType = "ledgers"
 	Transactions ResourceType = "transactions"
 	Balances     ResourceType = "balances"
+	Identities   ResourceType = "identities"
 )
 
 type CriteriaField string
@@ -128,3 +137,11 @@ const (
 	ReconciliationStrategyOneToMany ReconciliationStrategy = "one_to_many"
 	ReconciliationStrategyManyToOne ReconciliationStrategy = "many_to_one"
 )
+
+// HookType represents when a server-side hook is called relative to a transaction.
+type HookType string
+
+const (
+	HookTypePreTransaction  HookType = "PRE_TRANSACTION"
+	HookTypePostTransaction HookType = "POST_TRANSACTION"
+)
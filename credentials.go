@@ -0,0 +1,236 @@
+package blnkgo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// EnvAPIKey is the environment variable read by EnvCredentials for the API key
+	EnvAPIKey = "BLNK_API_KEY"
+	// EnvBearerToken is the environment variable read by EnvCredentials for the bearer token
+	EnvBearerToken = "BLNK_BEARER_TOKEN"
+)
+
+// Credentials are the secrets attached to every request. APIKey is sent as X-Blnk-Key and
+// BearerToken, when set, as an Authorization bearer header.
+type Credentials struct {
+	APIKey      string `json:"api_key"`
+	BearerToken string `json:"bearer_token"`
+}
+
+// CredentialsProvider is consulted for every request so keys can be rotated without
+// rebuilding the client. Implementations must be safe for concurrent use.
+type CredentialsProvider interface {
+	Credentials() (Credentials, error)
+}
+
+// CredentialsFunc adapts a function to a CredentialsProvider
+type CredentialsFunc func() (Credentials, error)
+
+func (f CredentialsFunc) Credentials() (Credentials, error) {
+	return f()
+}
+
+// StaticCredentials always returns the same credentials.
+type StaticCredentials Credentials
+
+func (s StaticCredentials) Credentials() (Credentials, error) {
+	return Credentials(s), nil
+}
+
+// EnvCredentials reads credentials from environment variables on every request.
+type EnvCredentials struct {
+	APIKeyVar      string
+	BearerTokenVar string
+}
+
+// NewEnvCredentials reads BLNK_API_KEY and BLNK_BEARER_TOKEN
+func NewEnvCredentials() *EnvCredentials {
+	return &EnvCredentials{APIKeyVar: EnvAPIKey, BearerTokenVar: EnvBearerToken}
+}
+
+func (e *EnvCredentials) Credentials() (Credentials, error) {
+	creds := Credentials{
+		APIKey:      os.Getenv(e.APIKeyVar),
+		BearerToken: os.Getenv(e.BearerTokenVar),
+	}
+	if creds.APIKey == "" && creds.BearerToken == "" {
+		return Credentials{}, fmt.Errorf("neither %s nor %s is set", e.APIKeyVar, e.BearerTokenVar)
+	}
+	return creds, nil
+}
+
+// DefaultMaxStaleCredentials is how long FileCredentials keeps serving the last good
+// credentials while the file can not be reloaded, unless WithMaxStaleCredentials says otherwise
+const DefaultMaxStaleCredentials = 15 * time.Minute
+
+// FileCredentials reads credentials from a file and reloads it when it changes on disk.
+// Failed reloads are logged through the client logger and the last good credentials are
+// served until they are older than the max stale duration.
+type FileCredentials struct {
+	path     string
+	interval time.Duration
+	maxStale time.Duration
+
+	mu        sync.RWMutex
+	creds     Credentials
+	modTime   time.Time
+	checkedAt time.Time
+	loadedAt  time.Time
+	logger    Logger
+}
+
+// FileCredentialsOption configures a FileCredentials
+type FileCredentialsOption func(*FileCredentials)
+
+// WithMaxStaleCredentials sets how long the last good credentials are served while the file
+// can not be reloaded, after that requests fail with the reload error. Zero never fails.
+func WithMaxStaleCredentials(d time.Duration) FileCredentialsOption {
+	return func(f *FileCredentials) {
+		f.maxStale = d
+	}
+}
+
+// NewFileCredentials loads path and checks it for changes at most once per interval
+func NewFileCredentials(path string, interval time.Duration, opts ...FileCredentialsOption) (*FileCredentials, error) {
+	f := &FileCredentials{path: path, interval: interval, maxStale: DefaultMaxStaleCredentials}
+	for _, opt := range opts {
+		opt(f)
+	}
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (f *FileCredentials) Credentials() (Credentials, error) {
+	f.mu.RLock()
+	creds, due := f.creds, time.Since(f.checkedAt) >= f.interval
+	f.mu.RUnlock()
+	if !due {
+		return creds, nil
+	}
+
+	//keep serving the last good credentials if the file is briefly unreadable mid-rotation
+	if err := f.reload(); err != nil {
+		f.mu.RLock()
+		logger, loadedAt := f.logger, f.loadedAt
+		f.mu.RUnlock()
+		if logger != nil {
+			logger.Error(fmt.Sprintf("unable to reload credentials from %s: %s", f.path, err.Error()))
+		}
+		if f.maxStale > 0 && time.Since(loadedAt) > f.maxStale {
+			return Credentials{}, fmt.Errorf("credentials from %s were last loaded at %s: %w", f.path, loadedAt.Format(time.RFC3339), err)
+		}
+		return creds, nil
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.creds, nil
+}
+
+func (f *FileCredentials) setLogger(logger Logger) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logger = logger
+}
+
+// reload rereads the file when it changed, a failed check is not retried before the next interval
+func (f *FileCredentials) reload() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.checkedAt = time.Now()
+
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	if !info.ModTime().Equal(f.modTime) {
+		data, err := os.ReadFile(f.path)
+		if err != nil {
+			return err
+		}
+		creds, err := parseCredentialsFile(data)
+		if err != nil {
+			return err
+		}
+		f.creds = creds
+		f.modTime = info.ModTime()
+	}
+	f.loadedAt = f.checkedAt
+	return nil
+}
+
+func parseCredentialsFile(data []byte) (Credentials, error) {
+	content := strings.TrimSpace(string(data))
+	if content == "" {
+		return Credentials{}, errors.New("credentials file is empty")
+	}
+	if !strings.HasPrefix(content, "{") {
+		return Credentials{APIKey: content}, nil
+	}
+	var creds Credentials
+	if err := json.Unmarshal([]byte(content), &creds); err != nil {
+		return Credentials{}, fmt.Errorf("invalid credentials file: %w", err)
+	}
+	return creds, nil
+}
+
+// SetCredentialsProvider swaps the provider used for subsequent requests, it is safe to
+// call while requests are in flight
+func (c *Client) SetCredentialsProvider(provider CredentialsProvider) {
+	c.credsMu.Lock()
+	defer c.credsMu.Unlock()
+	c.credentials = provider
+	c.attachCredentialsLogger()
+}
+
+// loggerSetter is implemented by providers that report problems through the client logger
+type loggerSetter interface {
+	setLogger(Logger)
+}
+
+func (c *Client) attachCredentialsLogger() {
+	if provider, ok := c.credentials.(loggerSetter); ok {
+		provider.setLogger(c.options.Logger)
+	}
+}
+
+// credentialsProvider returns the configured provider, falling back to the static ApiKey
+func (c *Client) credentialsProvider() CredentialsProvider {
+	c.credsMu.RLock()
+	defer c.credsMu.RUnlock()
+	if c.credentials != nil {
+		return c.credentials
+	}
+	if c.ApiKey != nil {
+		return StaticCredentials{APIKey: *c.ApiKey}
+	}
+	return nil
+}
+
+// setAuthHeaders adds the current credentials to req
+func (c *Client) setAuthHeaders(req *http.Request) error {
+	provider := c.credentialsProvider()
+	if provider == nil {
+		return nil
+	}
+	creds, err := provider.Credentials()
+	if err != nil {
+		return fmt.Errorf("unable to load credentials: %w", err)
+	}
+	if creds.APIKey != "" {
+		req.Header.Set("X-Blnk-Key", creds.APIKey)
+	}
+	if creds.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+creds.BearerToken)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package blnkgo_test
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, apiKey *string, opts ...blnkgo.ClientOption) *blnkgo.Client {
+	baseURL, err := url.Parse("http://localhost:5001/")
+	assert.NoError(t, err)
+	return blnkgo.NewClient(baseURL, apiKey, opts...)
+}
+
+func TestClient_NewRequest_StaticApiKey(t *testing.T) {
+	key := "static-key"
+	client := newTestClient(t, &key)
+
+	req, err := client.NewRequest("ledgers", http.MethodGet, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "static-key", req.Header.Get("X-Blnk-Key"))
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func TestClient_NewRequest_ProviderOverridesApiKey(t *testing.T) {
+	key := "static-key"
+	client := newTestClient(t, &key, blnkgo.WithCredentialsProvider(blnkgo.StaticCredentials{
+		APIKey:      "provider-key",
+		BearerToken: "token",
+	}))
+
+	req, err := client.NewRequest("ledgers", http.MethodPost, map[string]string{"name": "x"})
+	assert.NoError(t, err)
+	assert.Equal(t, "provider-key", req.Header.Get("X-Blnk-Key"))
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+}
+
+func TestClient_NewRequest_CredentialsFuncError(t *testing.T) {
+	client := newTestClient(t, nil, blnkgo.WithCredentialsProvider(blnkgo.CredentialsFunc(func() (blnkgo.Credentials, error) {
+		return blnkgo.Credentials{}, errors.New("vault unavailable")
+	})))
+
+	req, err := client.NewRequest("ledgers", http.MethodGet, nil)
+	assert.Nil(t, req)
+	assert.ErrorContains(t, err, "vault unavailable")
+}
+
+func TestClient_NewFileUploadRequest_UsesProvider(t *testing.T) {
+	client := newTestClient(t, nil, blnkgo.WithCredentialsProvider(blnkgo.StaticCredentials{APIKey: "upload-key"}))
+
+	req, err := client.NewFileUploadRequest("reconciliation/upload", "file", strings.NewReader("a,b\n"), "f.csv", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "upload-key", req.Header.Get("X-Blnk-Key"))
+}
+
+func TestEnvCredentials(t *testing.T) {
+	t.Setenv(blnkgo.EnvAPIKey, "env-key")
+	t.Setenv(blnkgo.EnvBearerToken, "")
+
+	creds, err := blnkgo.NewEnvCredentials().Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "env-key", creds.APIKey)
+
+	t.Setenv(blnkgo.EnvAPIKey, "")
+	_, err = blnkgo.NewEnvCredentials().Credentials()
+	assert.Error(t, err)
+}
+
+func TestFileCredentials_ReloadsOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blnk-key")
+	assert.NoError(t, os.WriteFile(path, []byte("first-key\n"), 0o600))
+
+	provider, err := blnkgo.NewFileCredentials(path, 0)
+	assert.NoError(t, err)
+
+	creds, err := provider.Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "first-key", creds.APIKey)
+
+	assert.NoError(t, os.WriteFile(path, []byte(`{"api_key":"second-key","bearer_token":"tok"}`), 0o600))
+	later := time.Now().Add(time.Second)
+	assert.NoError(t, os.Chtimes(path, later, later))
+
+	creds, err = provider.Credentials()
+	assert.NoError(t, err)
+	assert.Equal(t, "second-key", creds.APIKey)
+	assert.Equal(t, "tok", creds.BearerToken)
+}
+
+func TestFileCredentials_MissingFile(t *testing.T) {
+	provider, err := blnkgo.NewFileCredentials(filepath.Join(t.TempDir(), "missing"), time.Second)
+	assert.Error(t, err)
+	assert.Nil(t, provider)
+}
+
+// recordingLogger keeps the errors logged through it
+type recordingLogger struct {
+	mu     sync.Mutex
+	errors []string
+}
+
+func (l *recordingLogger) Info(string) {}
+
+func (l *recordingLogger) Error(msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors = append(l.errors, msg)
+}
+
+func TestFileCredentials_FailedReloadIsLoggedThenReturned(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "blnk-key")
+	assert.NoError(t, os.WriteFile(path, []byte("first-key\n"), 0o600))
+
+	provider, err := blnkgo.NewFileCredentials(path, 0, blnkgo.WithMaxStaleCredentials(50*time.Millisecond))
+	assert.NoError(t, err)
+	logger := &recordingLogger{}
+	client := newTestClient(t, nil, blnkgo.WithCredentialsProvider(provider), blnkgo.WithLogger(logger))
+
+	//the last good key is served while the file is missing mid-rotation
+	assert.NoError(t, os.Remove(path))
+	req, err := client.NewRequest("ledgers", http.MethodGet, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "first-key", req.Header.Get("X-Blnk-Key"))
+	assert.Len(t, logger.errors, 1)
+	assert.Contains(t, logger.errors[0], path)
+
+	//once it is older than the limit the reload error is returned
+	time.Sleep(60 * time.Millisecond)
+	_, err = client.NewRequest("ledgers", http.MethodGet, nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestClient_SetCredentialsProvider_Concurrent(t *testing.T) {
+	client := newTestClient(t, nil, blnkgo.WithCredentialsProvider(blnkgo.StaticCredentials{APIKey: "old"}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			req, err := client.NewRequest("ledgers", http.MethodGet, nil)
+			assert.NoError(t, err)
+			assert.Contains(t, []string{"old", "new"}, req.Header.Get("X-Blnk-Key"))
+		}()
+		go func() {
+			defer wg.Done()
+			client.SetCredentialsProvider(blnkgo.StaticCredentials{APIKey: "new"})
+		}()
+	}
+	wg.Wait()
+
+	req, err := client.NewRequest("ledgers", http.MethodGet, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", req.Header.Get("X-Blnk-Key"))
+}
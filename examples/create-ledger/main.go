@@ -2,17 +2,20 @@ package main
 
 import (
 	"fmt"
-	"net/url"
 	"time"
 
 	blnkgo "github.com/blnkfinance/blnk-go"
 )
 
 func main() {
-	baseURL, _ := url.Parse("http://localhost:5001/")
-	client := blnkgo.NewClient(baseURL, nil, blnkgo.WithTimeout(
+	//reads BLNK_BASE_URL, BLNK_API_KEY and the other BLNK_* settings from the environment
+	client, err := blnkgo.NewClientFromEnv(blnkgo.WithTimeout(
 		5*time.Second,
 	), blnkgo.WithRetry(2))
+	if err != nil {
+		fmt.Print(err.Error())
+		return
+	}
 
 	var ledgerBody blnkgo.CreateLedgerRequest = blnkgo.CreateLedgerRequest{
 		Name: "First Ledger",
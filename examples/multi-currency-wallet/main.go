@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -141,14 +142,21 @@ func main() {
 	}
 
 	fmt.Printf("%+v\n", eurTransactionBody)
-	_, resp, err = client.Transaction.Create(eurTransactionBody)
+	eurTransaction, resp, err := client.Transaction.Create(eurTransactionBody)
 	if err != nil {
 		fmt.Print(err.Error())
 		return
 	}
 	fmt.Println(resp.StatusCode)
-	//simulate waiting for the transactions to complete on the service by sleeping
-	time.Sleep(5 * time.Second)
+	//wait for both deposits to be applied before debiting the balances
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	for _, id := range []string{transaction.TransactionID, eurTransaction.TransactionID} {
+		if _, err = client.Transaction.WaitForStatus(ctx, id, blnkgo.PryTransactionStatusApplied); err != nil {
+			fmt.Print(err.Error())
+			return
+		}
+	}
 
 	//create a debit on usd balance by making it the source and destination the world
 	debitBody := blnkgo.CreateTransactionRequest{
@@ -194,8 +202,11 @@ func main() {
 	}
 	fmt.Printf("Exchange: %+v\n", exchange)
 	fmt.Printf("Exchange: %+v\n", resp)
-	//simulate waiting for the transactions to complete on the service by sleeping
-	time.Sleep(5 * time.Second)
+	//wait for the exchange to be applied before reading the balances
+	if _, err = client.Transaction.WaitForStatus(ctx, exchange.TransactionID, blnkgo.PryTransactionStatusApplied); err != nil {
+		fmt.Print(err.Error())
+		return
+	}
 
 	//get the balance of the usd balance
 	usdBalance, resp, err = client.LedgerBalance.Get(usdBalance.BalanceID)
@@ -4,6 +4,9 @@ go 1.22.2
 
 require github.com/blnkfinance/blnk-go v1.1.0
 
-require github.com/google/go-querystring v1.1.0 // indirect
+require (
+	github.com/google/go-querystring v1.1.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
 
-replace github.com/blnkfinance/blnk-go => ../../
\ No newline at end of file
+replace github.com/blnkfinance/blnk-go => ../../
@@ -0,0 +1,166 @@
+package blnkgo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Feature names a server capability that only some Blnk versions support.
+type Feature string
+
+const (
+	FeatureBulkTransactions      Feature = "bulk_transactions"
+	FeatureHooks                 Feature = "hooks"
+	FeatureIdentityTokenization  Feature = "identity_tokenization"
+	FeatureAPIKeys               Feature = "api_keys"
+	FeatureScheduledTransactions Feature = "scheduled_transactions"
+)
+
+var (
+	// ErrServerUnhealthy is returned by Health when the server reports it is not ready
+	ErrServerUnhealthy = errors.New("blnk server is not healthy")
+	// ErrUnsupportedFeature matches every UnsupportedFeatureError
+	ErrUnsupportedFeature = errors.New("unsupported by server version")
+)
+
+// UnsupportedFeatureError is returned by service methods that need a feature the
+// discovered server capabilities do not include.
+type UnsupportedFeatureError struct {
+	Feature       Feature
+	ServerVersion string
+}
+
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s is unsupported by server version %s", e.Feature, e.ServerVersion)
+}
+
+func (e *UnsupportedFeatureError) Is(target error) bool {
+	return target == ErrUnsupportedFeature
+}
+
+// HealthStatus is the server readiness report.
+type HealthStatus struct {
+	Status string `json:"status"`
+}
+
+// ServerCapabilities describes the server version and the optional features it supports.
+type ServerCapabilities struct {
+	Version      string    `json:"version"`
+	Features     []Feature `json:"features"`
+	DiscoveredAt time.Time `json:"-"`
+}
+
+// Supports reports whether feature is in the capability list
+func (s *ServerCapabilities) Supports(feature Feature) bool {
+	for _, f := range s.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// capabilityCache holds the capabilities discovered for a client
+type capabilityCache struct {
+	mu           sync.RWMutex
+	capabilities *ServerCapabilities
+}
+
+// Health checks that the server is reachable and ready, suitable for readiness probes.
+// ctx bounds the check independently of the client timeout. The request is sent once
+// without retries, and a server answering 5xx or a status other than UP yields
+// ErrServerUnhealthy along with the status it reported.
+func (c *Client) Health(ctx context.Context) (*HealthStatus, *http.Response, error) {
+	req, err := c.NewRequest("health", http.MethodGet, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.limiter != nil {
+		c.limiter.wait()
+	}
+	resp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	status := new(HealthStatus)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		//unready servers answer with an error code, the body still carries their status
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<16)).Decode(status)
+		return status, resp, fmt.Errorf("%w: http %d, status %q", ErrServerUnhealthy, resp.StatusCode, status.Status)
+	}
+	if err := c.DecodeResponse(resp, status); err != nil {
+		return nil, resp, err
+	}
+	if status.Status != "UP" {
+		return status, resp, fmt.Errorf("%w: status %q", ErrServerUnhealthy, status.Status)
+	}
+
+	return status, resp, nil
+}
+
+// Capabilities returns the server capabilities, discovering them on first use and serving
+// them from the client cache afterwards.
+func (c *Client) Capabilities() (*ServerCapabilities, error) {
+	c.capabilities.mu.RLock()
+	cached := c.capabilities.capabilities
+	c.capabilities.mu.RUnlock()
+	if cached != nil {
+		return cached, nil
+	}
+	return c.RefreshCapabilities()
+}
+
+// RefreshCapabilities discovers the server capabilities again, for example after an upgrade
+func (c *Client) RefreshCapabilities() (*ServerCapabilities, error) {
+	req, err := c.NewRequest("capabilities", http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities := new(ServerCapabilities)
+	if _, err := c.CallWithRetry(req, capabilities); err != nil {
+		return nil, err
+	}
+	capabilities.DiscoveredAt = time.Now()
+
+	c.capabilities.mu.Lock()
+	c.capabilities.capabilities = capabilities
+	c.capabilities.mu.Unlock()
+	return capabilities, nil
+}
+
+// RequireFeature fails fast with an *UnsupportedFeatureError when discovered capabilities
+// do not include feature. It never triggers discovery, so without a prior call to
+// Capabilities every feature is assumed to be available.
+func (c *Client) RequireFeature(feature Feature) error {
+	c.capabilities.mu.RLock()
+	capabilities := c.capabilities.capabilities
+	c.capabilities.mu.RUnlock()
+
+	if capabilities == nil || capabilities.Supports(feature) {
+		return nil
+	}
+	return &UnsupportedFeatureError{Feature: feature, ServerVersion: capabilities.Version}
+}
+
+// featureChecker is implemented by clients that know the server capabilities
+type featureChecker interface {
+	RequireFeature(feature Feature) error
+}
+
+// requireFeature checks feature when client supports capability checks
+func requireFeature(client ClientInterface, feature Feature) error {
+	if checker, ok := client.(featureChecker); ok {
+		return checker.RequireFeature(feature)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package blnkgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newServerClient returns a client pointed at a test server serving handler
+func newServerClient(t *testing.T, handler http.HandlerFunc, opts ...blnkgo.ClientOption) *blnkgo.Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return blnkgo.NewClient(baseURL, nil, opts...)
+}
+
+func TestClient_Health(t *testing.T) {
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/health", r.URL.Path)
+		_, _ = w.Write([]byte(`{"status":"UP"}`))
+	})
+
+	status, resp, err := client.Health(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "UP", status.Status)
+}
+
+func TestClient_Health_NotReady(t *testing.T) {
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"DOWN"}`))
+	})
+
+	status, _, err := client.Health(context.Background())
+	assert.True(t, errors.Is(err, blnkgo.ErrServerUnhealthy))
+	assert.Equal(t, "DOWN", status.Status)
+}
+
+func TestClient_Health_ServiceUnavailable(t *testing.T) {
+	calls := 0
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"status":"DOWN"}`))
+	}, blnkgo.WithRetry(3))
+
+	status, resp, err := client.Health(context.Background())
+	assert.ErrorIs(t, err, blnkgo.ErrServerUnhealthy)
+	require.NotNil(t, status)
+	assert.Equal(t, "DOWN", status.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_Capabilities_CachedAndEnforced(t *testing.T) {
+	calls := 0
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/capabilities":
+			calls++
+			_, _ = w.Write([]byte(`{"version":"0.6.0","features":["bulk_transactions"]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	})
+
+	//before discovery every feature is assumed available
+	assert.NoError(t, client.RequireFeature(blnkgo.FeatureHooks))
+
+	capabilities, err := client.Capabilities()
+	require.NoError(t, err)
+	assert.Equal(t, "0.6.0", capabilities.Version)
+	assert.True(t, capabilities.Supports(blnkgo.FeatureBulkTransactions))
+
+	_, err = client.Capabilities()
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	hooks, resp, err := client.Hook.List()
+	assert.Nil(t, hooks)
+	assert.Nil(t, resp)
+	assert.True(t, errors.Is(err, blnkgo.ErrUnsupportedFeature))
+	assert.EqualError(t, err, "hooks is unsupported by server version 0.6.0")
+}
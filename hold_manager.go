@@ -0,0 +1,370 @@
+package blnkgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// HoldPolicy decides what the HoldManager does with a hold that is about to expire.
+type HoldPolicy string
+
+const (
+	// HoldPolicyNone leaves the hold alone and lets the server expire it
+	HoldPolicyNone HoldPolicy = "none"
+	// HoldPolicyAutoVoid voids the hold before it expires
+	HoldPolicyAutoVoid HoldPolicy = "auto_void"
+	// HoldPolicyAutoCommit commits whatever is left of the hold before it expires
+	HoldPolicyAutoCommit HoldPolicy = "auto_commit"
+)
+
+// ErrHoldNotFound is returned when a hold is not tracked by the HoldStore
+var ErrHoldNotFound = errors.New("hold not found")
+
+// Hold is an inflight transaction tracked by the HoldManager.
+type Hold struct {
+	TransactionID string                   `json:"transaction_id"`
+	Request       CreateTransactionRequest `json:"request"`
+	ExpiresAt     time.Time                `json:"expires_at"`
+	Policy        HoldPolicy               `json:"policy"`
+	Notified      bool                     `json:"notified"`
+	Extensions    int                      `json:"extensions"`
+	CreatedAt     time.Time                `json:"created_at"`
+}
+
+// HoldStore persists the holds tracked by a HoldManager.
+type HoldStore interface {
+	Save(hold Hold) error
+	Get(transactionID string) (*Hold, error)
+	Delete(transactionID string) error
+	List() ([]Hold, error)
+}
+
+// MemoryHoldStore is an in-process HoldStore, holds are lost when the process exits.
+type MemoryHoldStore struct {
+	mu    sync.RWMutex
+	holds map[string]Hold
+}
+
+func NewMemoryHoldStore() *MemoryHoldStore {
+	return &MemoryHoldStore{holds: make(map[string]Hold)}
+}
+
+func (s *MemoryHoldStore) Save(hold Hold) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.holds[hold.TransactionID] = hold
+	return nil
+}
+
+func (s *MemoryHoldStore) Get(transactionID string) (*Hold, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	hold, ok := s.holds[transactionID]
+	if !ok {
+		return nil, ErrHoldNotFound
+	}
+	return &hold, nil
+}
+
+func (s *MemoryHoldStore) Delete(transactionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.holds, transactionID)
+	return nil
+}
+
+func (s *MemoryHoldStore) List() ([]Hold, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	holds := make([]Hold, 0, len(s.holds))
+	for _, hold := range s.holds {
+		holds = append(holds, hold)
+	}
+	sort.Slice(holds, func(i, j int) bool { return holds[i].ExpiresAt.Before(holds[j].ExpiresAt) })
+	return holds, nil
+}
+
+// HoldManager tracks inflight transactions created through the SDK, warns before they
+// expire and voids or commits them according to their HoldPolicy.
+type HoldManager struct {
+	transactions  *TransactionService
+	store         HoldStore
+	defaultPolicy HoldPolicy
+	notifyBefore  time.Duration
+	actBefore     time.Duration
+	interval      time.Duration
+	onExpiring    func(Hold)
+	onResolved    func(Hold, *Transaction, error)
+	now           func() time.Time
+}
+
+type HoldManagerOption func(*HoldManager)
+
+// WithHoldStore sets the store used to persist holds, defaults to a MemoryHoldStore
+func WithHoldStore(store HoldStore) HoldManagerOption {
+	return func(m *HoldManager) {
+		m.store = store
+	}
+}
+
+// WithDefaultHoldPolicy sets the policy applied to holds created without one
+func WithDefaultHoldPolicy(policy HoldPolicy) HoldManagerOption {
+	return func(m *HoldManager) {
+		m.defaultPolicy = policy
+	}
+}
+
+// WithHoldNotifyBefore sets how long before expiry the expiring callback fires
+func WithHoldNotifyBefore(d time.Duration) HoldManagerOption {
+	return func(m *HoldManager) {
+		m.notifyBefore = d
+	}
+}
+
+// WithHoldActBefore sets how long before expiry the hold policy is applied
+func WithHoldActBefore(d time.Duration) HoldManagerOption {
+	return func(m *HoldManager) {
+		m.actBefore = d
+	}
+}
+
+// WithHoldSweepInterval sets how often Run sweeps the tracked holds
+func WithHoldSweepInterval(d time.Duration) HoldManagerOption {
+	return func(m *HoldManager) {
+		m.interval = d
+	}
+}
+
+// WithOnHoldExpiring registers a callback fired once per hold when it nears expiry
+func WithOnHoldExpiring(fn func(Hold)) HoldManagerOption {
+	return func(m *HoldManager) {
+		m.onExpiring = fn
+	}
+}
+
+// WithOnHoldResolved registers a callback fired after the hold policy has been applied
+func WithOnHoldResolved(fn func(Hold, *Transaction, error)) HoldManagerOption {
+	return func(m *HoldManager) {
+		m.onResolved = fn
+	}
+}
+
+func NewHoldManager(transactions *TransactionService, opts ...HoldManagerOption) *HoldManager {
+	m := &HoldManager{
+		transactions:  transactions,
+		store:         NewMemoryHoldStore(),
+		defaultPolicy: HoldPolicyAutoVoid,
+		notifyBefore:  10 * time.Minute,
+		actBefore:     time.Minute,
+		interval:      30 * time.Second,
+		now:           time.Now,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Create creates an inflight transaction and starts tracking it. The request must carry an
+// InflightExpiryDate, an empty policy falls back to the manager default.
+func (m *HoldManager) Create(body CreateTransactionRequest, policy HoldPolicy) (*Transaction, error) {
+	if body.InflightExpiryDate == nil {
+		return nil, fmt.Errorf("InflightExpiryDate is required to track a hold")
+	}
+	body.Inflight = true
+
+	transaction, _, err := m.transactions.Create(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.track(transaction.TransactionID, body, policy, 0); err != nil {
+		return transaction, err
+	}
+	return transaction, nil
+}
+
+// Get returns a tracked hold
+func (m *HoldManager) Get(transactionID string) (*Hold, error) {
+	return m.store.Get(transactionID)
+}
+
+// List returns every tracked hold ordered by expiry
+func (m *HoldManager) List() ([]Hold, error) {
+	return m.store.List()
+}
+
+// Forget stops tracking a hold without touching the transaction
+func (m *HoldManager) Forget(transactionID string) error {
+	return m.store.Delete(transactionID)
+}
+
+// Extend keeps a hold alive past its expiry. Blnk can not move the expiry of an existing
+// inflight transaction, so the remaining amount is re-authorized as a new inflight
+// transaction expiring at expiresAt, and the old one is only voided once the new one is
+// INFLIGHT. There is never a window without a hold, instead the remaining amount is held
+// twice until the old hold is voided, so the balance must cover both or the request must
+// allow overdraft. When the new hold is rejected the old one is kept and an error returned,
+// and when ctx ends before the new hold settles both stay tracked and are resolved by their
+// policy. The new hold is returned.
+func (m *HoldManager) Extend(ctx context.Context, transactionID string, expiresAt time.Time) (*Hold, error) {
+	hold, err := m.store.Get(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if !expiresAt.After(hold.ExpiresAt) {
+		return nil, fmt.Errorf("new expiry must be after the current expiry %s", hold.ExpiresAt.Format(time.RFC3339))
+	}
+
+	summary, err := m.transactions.GetInflightSummary(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if summary.PreciseRemaining == 0 {
+		return nil, fmt.Errorf("%w: nothing left to extend on %s", ErrNotInflight, transactionID)
+	}
+
+	body := hold.Request
+	body.Amount = summary.Remaining
+	body.PreciseAmount = 0
+	body.InflightExpiryDate = &expiresAt
+	body.Reference = fmt.Sprintf("%s-ext-%d", hold.Request.Reference, hold.Extensions+1)
+	body.Inflight = true
+
+	transaction, _, err := m.transactions.Create(body)
+	if err != nil {
+		return nil, err
+	}
+	//track the new hold before waiting on it so it is never left unmanaged,
+	//keeping the base reference so later extensions do not stack suffixes
+	body.Reference = hold.Request.Reference
+	if err := m.track(transaction.TransactionID, body, hold.Policy, hold.Extensions+1); err != nil {
+		return nil, fmt.Errorf("tracking extension %s of %s: %w", transaction.TransactionID, transactionID, err)
+	}
+
+	//new transactions are queued, the old hold is kept until the new one holds the funds
+	if _, err := m.transactions.WaitForStatus(ctx, transaction.TransactionID, PryTransactionStatusInFlight); err != nil {
+		var rejected *TransactionRejectedError
+		var final *UnexpectedFinalStatusError
+		if errors.As(err, &rejected) || errors.As(err, &final) {
+			err = errors.Join(err, m.store.Delete(transaction.TransactionID))
+		}
+		return nil, fmt.Errorf("extending %s: %w", transactionID, err)
+	}
+
+	if _, err := m.transactions.VoidInflight(transactionID); err != nil {
+		return nil, errors.Join(fmt.Errorf("voiding %s: %w", transactionID, err), m.rollbackExtension(transaction.TransactionID))
+	}
+	if err := m.store.Delete(transactionID); err != nil {
+		return nil, err
+	}
+	return m.store.Get(transaction.TransactionID)
+}
+
+// rollbackExtension voids an INFLIGHT hold created by a failed Extend so only the original
+// authorization stays live. If the void fails the new hold stays tracked and is
+// resolved by its policy like any other hold.
+func (m *HoldManager) rollbackExtension(transactionID string) error {
+	if _, err := m.transactions.VoidInflight(transactionID); err != nil {
+		return fmt.Errorf("voiding extension %s: %w", transactionID, err)
+	}
+	return m.store.Delete(transactionID)
+}
+
+// Run sweeps the tracked holds every interval until ctx is cancelled
+func (m *HoldManager) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := m.Sweep(ctx); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sweep fires expiring callbacks and applies the hold policy to holds that are due.
+// Failures on a single hold are reported through the resolved callback and do not stop the sweep.
+func (m *HoldManager) Sweep(ctx context.Context) error {
+	holds, err := m.store.List()
+	if err != nil {
+		return err
+	}
+
+	now := m.now()
+	for _, hold := range holds {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if hold.Notified || now.Before(hold.ExpiresAt.Add(-m.notifyBefore)) {
+			continue
+		}
+		hold.Notified = true
+		if err := m.store.Save(hold); err != nil {
+			return err
+		}
+		if m.onExpiring != nil {
+			m.onExpiring(hold)
+		}
+	}
+
+	//re-read the store since expiring callbacks may have extended or resolved holds
+	holds, err = m.store.List()
+	if err != nil {
+		return err
+	}
+	for _, hold := range holds {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if now.Before(hold.ExpiresAt.Add(-m.actBefore)) {
+			continue
+		}
+		m.resolve(hold)
+	}
+	return nil
+}
+
+func (m *HoldManager) resolve(hold Hold) {
+	var transaction *Transaction
+	var err error
+	switch hold.Policy {
+	case HoldPolicyAutoVoid:
+		transaction, err = m.transactions.VoidInflight(hold.TransactionID)
+	case HoldPolicyAutoCommit:
+		transaction, err = m.transactions.CommitInflight(hold.TransactionID, 0)
+	}
+
+	//holds that were committed or voided elsewhere are no longer ours to manage
+	if err == nil || errors.Is(err, ErrNotInflight) || errors.Is(err, ErrInflightOverCommit) || hold.Policy == HoldPolicyNone {
+		if delErr := m.store.Delete(hold.TransactionID); delErr != nil && err == nil {
+			err = delErr
+		}
+	}
+	if m.onResolved != nil {
+		m.onResolved(hold, transaction, err)
+	}
+}
+
+func (m *HoldManager) track(transactionID string, body CreateTransactionRequest, policy HoldPolicy, extensions int) error {
+	if policy == "" {
+		policy = m.defaultPolicy
+	}
+	return m.store.Save(Hold{
+		TransactionID: transactionID,
+		Request:       body,
+		ExpiresAt:     *body.InflightExpiryDate,
+		Policy:        policy,
+		Extensions:    extensions,
+		CreatedAt:     m.now(),
+	})
+}
@@ -0,0 +1,250 @@
+package blnkgo_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func holdRequest(expiry time.Time) blnkgo.CreateTransactionRequest {
+	return blnkgo.CreateTransactionRequest{
+		ParentTransaction: blnkgo.ParentTransaction{
+			Amount:      100,
+			Reference:   "hold-1",
+			Precision:   100,
+			Currency:    "USD",
+			Source:      "@card",
+			Destination: "@merchant",
+		},
+		InflightExpiryDate: &expiry,
+	}
+}
+
+func TestHoldManager_Create_RequiresExpiry(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	manager := blnkgo.NewHoldManager(svc)
+
+	body := holdRequest(time.Now())
+	body.InflightExpiryDate = nil
+	transaction, err := manager.Create(body, blnkgo.HoldPolicyAutoVoid)
+
+	assert.Error(t, err)
+	assert.Nil(t, transaction)
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
+
+func TestHoldManager_Create_TracksHold(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	manager := blnkgo.NewHoldManager(svc, blnkgo.WithDefaultHoldPolicy(blnkgo.HoldPolicyAutoCommit))
+
+	expiry := time.Now().Add(time.Hour)
+	body := holdRequest(expiry)
+	sent := body
+	sent.Inflight = true
+
+	mockClient.On("NewRequest", "transactions", http.MethodPost, sent).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusCreated}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.Transaction) = blnkgo.Transaction{TransactionID: "tx-1"}
+	})
+
+	transaction, err := manager.Create(body, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "tx-1", transaction.TransactionID)
+
+	hold, err := manager.Get("tx-1")
+	assert.NoError(t, err)
+	assert.Equal(t, blnkgo.HoldPolicyAutoCommit, hold.Policy)
+	assert.True(t, expiry.Equal(hold.ExpiresAt))
+	mockClient.AssertExpectations(t)
+}
+
+func TestHoldManager_Sweep_NotifiesAndForgetsWithoutPolicy(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	store := blnkgo.NewMemoryHoldStore()
+
+	var expiring, resolved []string
+	manager := blnkgo.NewHoldManager(svc,
+		blnkgo.WithHoldStore(store),
+		blnkgo.WithHoldNotifyBefore(time.Hour),
+		blnkgo.WithHoldActBefore(time.Minute),
+		blnkgo.WithOnHoldExpiring(func(h blnkgo.Hold) { expiring = append(expiring, h.TransactionID) }),
+		blnkgo.WithOnHoldResolved(func(h blnkgo.Hold, _ *blnkgo.Transaction, err error) {
+			assert.NoError(t, err)
+			resolved = append(resolved, h.TransactionID)
+		}),
+	)
+
+	now := time.Now()
+	assert.NoError(t, store.Save(blnkgo.Hold{TransactionID: "soon", ExpiresAt: now.Add(30 * time.Minute), Policy: blnkgo.HoldPolicyNone}))
+	assert.NoError(t, store.Save(blnkgo.Hold{TransactionID: "due", ExpiresAt: now.Add(30 * time.Second), Policy: blnkgo.HoldPolicyNone}))
+	assert.NoError(t, store.Save(blnkgo.Hold{TransactionID: "later", ExpiresAt: now.Add(2 * time.Hour), Policy: blnkgo.HoldPolicyNone}))
+
+	assert.NoError(t, manager.Sweep(context.Background()))
+	assert.ElementsMatch(t, []string{"soon", "due"}, expiring)
+	assert.Equal(t, []string{"due"}, resolved)
+
+	holds, err := manager.List()
+	assert.NoError(t, err)
+	assert.Len(t, holds, 2)
+	assert.Equal(t, "soon", holds[0].TransactionID)
+	assert.True(t, holds[0].Notified)
+
+	//a second sweep must not notify again
+	assert.NoError(t, manager.Sweep(context.Background()))
+	assert.Len(t, expiring, 2)
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
+
+func TestHoldManager_Sweep_AutoVoid(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupInflightMocks(mockClient, inflightTransaction(100), nil)
+
+	putReq := &http.Request{Method: http.MethodPut}
+	mockClient.On("NewRequest", "transactions/inflight/tx-1", http.MethodPut, blnkgo.UpdateStatus{Status: blnkgo.InflightStatusVoid}).Return(putReq, nil)
+	mockClient.On("CallWithRetry", putReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.Transaction) = commitTransaction(100, blnkgo.PryTransactionStatusVoid)
+	})
+
+	store := blnkgo.NewMemoryHoldStore()
+	assert.NoError(t, store.Save(blnkgo.Hold{TransactionID: "tx-1", ExpiresAt: time.Now(), Policy: blnkgo.HoldPolicyAutoVoid}))
+
+	var voided *blnkgo.Transaction
+	manager := blnkgo.NewHoldManager(svc, blnkgo.WithHoldStore(store), blnkgo.WithOnHoldResolved(func(_ blnkgo.Hold, tx *blnkgo.Transaction, err error) {
+		assert.NoError(t, err)
+		voided = tx
+	}))
+
+	assert.NoError(t, manager.Sweep(context.Background()))
+	assert.NotNil(t, voided)
+	assert.Equal(t, blnkgo.PryTransactionStatusVoid, voided.Status)
+
+	_, err := store.Get("tx-1")
+	assert.ErrorIs(t, err, blnkgo.ErrHoldNotFound)
+	mockClient.AssertExpectations(t)
+}
+
+// extendServer fakes the endpoints Extend uses. Each GET of a transaction returns the next
+// of its statuses and repeats the last one, voiding a transaction in failVoid gets a 400.
+type extendServer struct {
+	mu       sync.Mutex
+	statuses map[string][]blnkgo.PryTransactionStatus
+	failVoid map[string]bool
+	voided   []string
+}
+
+func (f *extendServer) handle(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/transactions":
+		_ = json.NewEncoder(w).Encode(blnkgo.Transaction{TransactionID: "tx-2", ParentTransaction: blnkgo.ParentTransaction{Status: blnkgo.PryTransactionStatusQueued}})
+	case r.Method == http.MethodPost && r.URL.Path == "/search/transactions":
+		_, _ = w.Write([]byte(`{"found":0,"hits":[]}`))
+	case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/transactions/inflight/"):
+		id := strings.TrimPrefix(r.URL.Path, "/transactions/inflight/")
+		if f.failVoid[id] {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"void failed"}`))
+			return
+		}
+		f.voided = append(f.voided, id)
+		f.statuses[id] = []blnkgo.PryTransactionStatus{blnkgo.PryTransactionStatusVoid}
+		_ = json.NewEncoder(w).Encode(blnkgo.Transaction{TransactionID: id})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/transactions/"):
+		id := strings.TrimPrefix(r.URL.Path, "/transactions/")
+		statuses := f.statuses[id]
+		if len(statuses) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if len(statuses) > 1 {
+			f.statuses[id] = statuses[1:]
+		}
+		_ = json.NewEncoder(w).Encode(blnkgo.Transaction{
+			TransactionID:     id,
+			ParentTransaction: blnkgo.ParentTransaction{Amount: 100, Precision: 100, Currency: "USD", Status: statuses[0]},
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func setupExtend(t *testing.T, extension []blnkgo.PryTransactionStatus, failVoid ...string) (*extendServer, *blnkgo.HoldManager, *blnkgo.MemoryHoldStore, time.Time) {
+	fake := &extendServer{
+		statuses: map[string][]blnkgo.PryTransactionStatus{
+			"tx-1": {blnkgo.PryTransactionStatusInFlight},
+			"tx-2": extension,
+		},
+		failVoid: make(map[string]bool),
+	}
+	for _, id := range failVoid {
+		fake.failVoid[id] = true
+	}
+	client := newServerClient(t, fake.handle)
+	store := blnkgo.NewMemoryHoldStore()
+	manager := blnkgo.NewHoldManager(client.Transaction, blnkgo.WithHoldStore(store))
+
+	expiry := time.Now().Add(time.Hour)
+	require.NoError(t, store.Save(blnkgo.Hold{
+		TransactionID: "tx-1",
+		Request:       holdRequest(expiry),
+		ExpiresAt:     expiry,
+		Policy:        blnkgo.HoldPolicyAutoCommit,
+	}))
+	return fake, manager, store, expiry
+}
+
+func TestHoldManager_Extend(t *testing.T) {
+	fake, manager, store, expiry := setupExtend(t, []blnkgo.PryTransactionStatus{blnkgo.PryTransactionStatusQueued, blnkgo.PryTransactionStatusInFlight})
+
+	hold, err := manager.Extend(context.Background(), "tx-1", expiry.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "tx-2", hold.TransactionID)
+	assert.Equal(t, 1, hold.Extensions)
+	assert.Equal(t, "hold-1", hold.Request.Reference)
+	assert.Equal(t, []string{"tx-1"}, fake.voided)
+
+	_, err = store.Get("tx-1")
+	assert.ErrorIs(t, err, blnkgo.ErrHoldNotFound)
+}
+
+func TestHoldManager_Extend_RejectedExtensionKeepsOriginal(t *testing.T) {
+	fake, manager, store, expiry := setupExtend(t, []blnkgo.PryTransactionStatus{blnkgo.PryTransactionStatusQueued, blnkgo.PryTransactionStatusRejected})
+
+	hold, err := manager.Extend(context.Background(), "tx-1", expiry.Add(time.Hour))
+	var rejected *blnkgo.TransactionRejectedError
+	assert.ErrorAs(t, err, &rejected)
+	assert.Nil(t, hold)
+	assert.Empty(t, fake.voided)
+
+	//the original still holds the funds and stays tracked, the rejected extension does not
+	_, err = store.Get("tx-1")
+	assert.NoError(t, err)
+	_, err = store.Get("tx-2")
+	assert.ErrorIs(t, err, blnkgo.ErrHoldNotFound)
+}
+
+func TestHoldManager_Extend_VoidsNewHoldWhenOldVoidFails(t *testing.T) {
+	fake, manager, store, expiry := setupExtend(t, []blnkgo.PryTransactionStatus{blnkgo.PryTransactionStatusQueued, blnkgo.PryTransactionStatusInFlight}, "tx-1")
+
+	hold, err := manager.Extend(context.Background(), "tx-1", expiry.Add(time.Hour))
+	assert.ErrorContains(t, err, "voiding tx-1")
+	assert.Nil(t, hold)
+	assert.Equal(t, []string{"tx-2"}, fake.voided)
+
+	//the original hold is still tracked and the voided extension is not
+	_, err = store.Get("tx-1")
+	assert.NoError(t, err)
+	_, err = store.Get("tx-2")
+	assert.ErrorIs(t, err, blnkgo.ErrHoldNotFound)
+}
@@ -0,0 +1,144 @@
+package blnkgo
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type HookService service
+
+// Hook represents a webhook the Blnk server calls before or after processing a transaction.
+type Hook struct {
+	Name       string   `json:"name"`
+	URL        string   `json:"url"`
+	Type       HookType `json:"type"`
+	Active     bool     `json:"active"`
+	Timeout    int      `json:"timeout,omitempty"`     // seconds
+	RetryCount int      `json:"retry_count,omitempty"` // attempts after the first failure
+}
+
+// HookResp extends Hook with the fields assigned by the server.
+type HookResp struct {
+	Hook
+	ID          string     `json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastRun     *time.Time `json:"last_run,omitempty"`
+	LastSuccess bool       `json:"last_success"`
+}
+
+func validateHook(hook Hook) error {
+	if hook.URL == "" {
+		return fmt.Errorf("url is required")
+	}
+	if hook.Type != HookTypePreTransaction && hook.Type != HookTypePostTransaction {
+		return fmt.Errorf("invalid hook type: %s", hook.Type)
+	}
+	if hook.Timeout < 0 || hook.RetryCount < 0 {
+		return fmt.Errorf("timeout and retry_count can not be negative")
+	}
+	return nil
+}
+
+func (s *HookService) Create(hook Hook) (*HookResp, *http.Response, error) {
+	if err := requireFeature(s.client, FeatureHooks); err != nil {
+		return nil, nil, err
+	}
+	if err := validateHook(hook); err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("hooks", http.MethodPost, hook)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hookResp := new(HookResp)
+	resp, err := s.client.CallWithRetry(req, hookResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return hookResp, resp, nil
+}
+
+func (s *HookService) Get(hookID string) (*HookResp, *http.Response, error) {
+	if err := requireFeature(s.client, FeatureHooks); err != nil {
+		return nil, nil, err
+	}
+	if hookID == "" {
+		return nil, nil, fmt.Errorf("hookID is required")
+	}
+	req, err := s.client.NewRequest("hooks/"+hookID, http.MethodGet, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hookResp := new(HookResp)
+	resp, err := s.client.CallWithRetry(req, hookResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return hookResp, resp, nil
+}
+
+func (s *HookService) List() ([]HookResp, *http.Response, error) {
+	if err := requireFeature(s.client, FeatureHooks); err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("hooks", http.MethodGet, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var hooks []HookResp
+	resp, err := s.client.CallWithRetry(req, &hooks)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return hooks, resp, nil
+}
+
+func (s *HookService) Update(hookID string, hook Hook) (*HookResp, *http.Response, error) {
+	if err := requireFeature(s.client, FeatureHooks); err != nil {
+		return nil, nil, err
+	}
+	if hookID == "" {
+		return nil, nil, fmt.Errorf("hookID is required")
+	}
+	if err := validateHook(hook); err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequest("hooks/"+hookID, http.MethodPut, hook)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hookResp := new(HookResp)
+	resp, err := s.client.CallWithRetry(req, hookResp)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return hookResp, resp, nil
+}
+
+func (s *HookService) Delete(hookID string) (*http.Response, error) {
+	if err := requireFeature(s.client, FeatureHooks); err != nil {
+		return nil, err
+	}
+	if hookID == "" {
+		return nil, fmt.Errorf("hookID is required")
+	}
+	req, err := s.client.NewRequest("hooks/"+hookID, http.MethodDelete, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.CallWithRetry(req, nil)
+}
+
+func NewHookService(client ClientInterface) *HookService {
+	return &HookService{client: client}
+}
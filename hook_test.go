@@ -0,0 +1,138 @@
+package blnkgo_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupHookService() (*MockClient, *blnkgo.HookService) {
+	mockClient := &MockClient{}
+	svc := blnkgo.NewHookService(mockClient)
+	return mockClient, svc
+}
+
+func sampleHook() blnkgo.Hook {
+	return blnkgo.Hook{
+		Name:       "fraud check",
+		URL:        "https://hooks.example.com/fraud",
+		Type:       blnkgo.HookTypePreTransaction,
+		Active:     true,
+		Timeout:    5,
+		RetryCount: 3,
+	}
+}
+
+func TestHookService_Create_Success(t *testing.T) {
+	mockClient, svc := setupHookService()
+	hook := sampleHook()
+
+	expectedResp := &blnkgo.HookResp{Hook: hook, ID: "hook-123", CreatedAt: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	mockClient.On("NewRequest", "hooks", http.MethodPost, hook).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusCreated}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.HookResp) = *expectedResp
+	})
+
+	resp, httpResp, err := svc.Create(hook)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, httpResp.StatusCode)
+	assert.Equal(t, expectedResp, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestHookService_Create_InvalidType(t *testing.T) {
+	mockClient, svc := setupHookService()
+	hook := sampleHook()
+	hook.Type = "DURING_TRANSACTION"
+
+	resp, httpResp, err := svc.Create(hook)
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Nil(t, httpResp)
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
+
+func TestHookService_Create_ServerError(t *testing.T) {
+	mockClient, svc := setupHookService()
+	hook := sampleHook()
+
+	mockClient.On("NewRequest", "hooks", http.MethodPost, hook).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusInternalServerError}, errors.New("server error"))
+
+	resp, httpResp, err := svc.Create(hook)
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	assert.Equal(t, http.StatusInternalServerError, httpResp.StatusCode)
+	mockClient.AssertExpectations(t)
+}
+
+func TestHookService_Get_Success(t *testing.T) {
+	mockClient, svc := setupHookService()
+
+	mockClient.On("NewRequest", "hooks/hook-123", http.MethodGet, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.HookResp) = blnkgo.HookResp{Hook: sampleHook(), ID: "hook-123"}
+	})
+
+	resp, _, err := svc.Get("hook-123")
+	assert.NoError(t, err)
+	assert.Equal(t, "hook-123", resp.ID)
+	mockClient.AssertExpectations(t)
+}
+
+func TestHookService_List_Success(t *testing.T) {
+	mockClient, svc := setupHookService()
+
+	expected := []blnkgo.HookResp{{Hook: sampleHook(), ID: "hook-1"}, {Hook: sampleHook(), ID: "hook-2"}}
+	mockClient.On("NewRequest", "hooks", http.MethodGet, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]blnkgo.HookResp) = expected
+	})
+
+	hooks, _, err := svc.List()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, hooks)
+	mockClient.AssertExpectations(t)
+}
+
+func TestHookService_Update_Success(t *testing.T) {
+	mockClient, svc := setupHookService()
+	hook := sampleHook()
+	hook.Active = false
+
+	mockClient.On("NewRequest", "hooks/hook-123", http.MethodPut, hook).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.HookResp) = blnkgo.HookResp{Hook: hook, ID: "hook-123"}
+	})
+
+	resp, _, err := svc.Update("hook-123", hook)
+	assert.NoError(t, err)
+	assert.False(t, resp.Active)
+	mockClient.AssertExpectations(t)
+}
+
+func TestHookService_Delete_Success(t *testing.T) {
+	mockClient, svc := setupHookService()
+
+	mockClient.On("NewRequest", "hooks/hook-123", http.MethodDelete, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, nil).Return(&http.Response{StatusCode: http.StatusNoContent}, nil)
+
+	httpResp, err := svc.Delete("hook-123")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, httpResp.StatusCode)
+	mockClient.AssertExpectations(t)
+}
+
+func TestHookService_Delete_EmptyID(t *testing.T) {
+	mockClient, svc := setupHookService()
+
+	httpResp, err := svc.Delete("")
+	assert.Error(t, err)
+	assert.Nil(t, httpResp)
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
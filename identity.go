@@ -3,10 +3,18 @@ package blnkgo
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 )
 
-type IdentityService service
+// identitySearchFields are the fields matched by the query of IdentityService.Search
+const identitySearchFields = "first_name,last_name,other_names,organization_name,email_address,phone_number"
+
+// IdentityService runs its validators on every Create and Update on top of the built-in checks
+type IdentityService struct {
+	client     ClientInterface
+	validators []IdentityValidator
+}
 
 type Identity struct {
 	IdentityType     IdentityType           `json:"identity_type"`
@@ -36,7 +44,7 @@ type IdentityResponse struct {
 
 func (s *IdentityService) Create(identity Identity) (*IdentityResponse, *http.Response, error) {
 	//validate the identity
-	if err := ValidateCreateIdentity(identity); err != nil {
+	if err := ValidateCreateIdentity(identity, s.validators...); err != nil {
 		return nil, nil, err
 	}
 	identityResponse := new(IdentityResponse)
@@ -79,6 +87,12 @@ func (s *IdentityService) List() ([]*IdentityResponse, *http.Response, error) {
 }
 
 func (s *IdentityService) Update(identityId string, identity *Identity) (*IdentityResponse, *http.Response, error) {
+	if identity == nil {
+		return nil, nil, fmt.Errorf("identity is required")
+	}
+	if err := ValidateUpdateIdentity(*identity, s.validators...); err != nil {
+		return nil, nil, err
+	}
 	var identityResponse *IdentityResponse
 	u := fmt.Sprintf("identities/%s", identityId)
 	req, err := s.client.NewRequest(u, http.MethodPut, identity)
@@ -92,6 +106,101 @@ func (s *IdentityService) Update(identityId string, identity *Identity) (*Identi
 	return identityResponse, resp, nil
 }
 
-func NewIdentityService(client ClientInterface) *IdentityService {
-	return &IdentityService{client: client}
+func (s *IdentityService) Delete(identityId string) (*http.Response, error) {
+	if identityId == "" {
+		return nil, fmt.Errorf("identityId is required")
+	}
+	u := fmt.Sprintf("identities/%s", identityId)
+	req, err := s.client.NewRequest(u, http.MethodDelete, nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.CallWithRetry(req, nil)
+}
+
+// IdentityFilter narrows the identities returned by ListWithFilter and Search.
+// Zero values are ignored, Page starts at 1.
+type IdentityFilter struct {
+	IdentityType IdentityType
+	Category     string
+	Country      string
+	CreatedFrom  time.Time
+	CreatedTo    time.Time
+	Page         int
+	PerPage      int
+}
+
+// IdentityPage is one page of identities, Found is the total number of matches.
+type IdentityPage struct {
+	Identities []*IdentityResponse
+	Found      int
+	Page       int
+}
+
+// filterBy builds the search filter for f
+func (f IdentityFilter) filterBy() string {
+	var filters []string
+	if f.IdentityType != "" {
+		filters = append(filters, fmt.Sprintf("identity_type:=%s", f.IdentityType))
+	}
+	if f.Category != "" {
+		filters = append(filters, fmt.Sprintf("category:=%s", f.Category))
+	}
+	if f.Country != "" {
+		filters = append(filters, fmt.Sprintf("country:=%s", f.Country))
+	}
+	if !f.CreatedFrom.IsZero() {
+		filters = append(filters, fmt.Sprintf("created_at:>=%d", f.CreatedFrom.Unix()))
+	}
+	if !f.CreatedTo.IsZero() {
+		filters = append(filters, fmt.Sprintf("created_at:<=%d", f.CreatedTo.Unix()))
+	}
+	return strings.Join(filters, " && ")
+}
+
+// ListWithFilter lists one page of identities matching filter, newest first.
+func (s *IdentityService) ListWithFilter(filter IdentityFilter) (*IdentityPage, *http.Response, error) {
+	return s.Search("*", filter)
+}
+
+// Search finds identities whose name, organization, email or phone number match query,
+// narrowed by filter.
+func (s *IdentityService) Search(query string, filter IdentityFilter) (*IdentityPage, *http.Response, error) {
+	if query == "" {
+		return nil, nil, fmt.Errorf("query is required, use * to match every identity")
+	}
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && filter.CreatedTo.Before(filter.CreatedFrom) {
+		return nil, nil, fmt.Errorf("created to must not be before created from")
+	}
+
+	sortBy := "created_at:desc"
+	params := SearchParams{Q: query, SortBy: &sortBy}
+	if query != "*" {
+		queryBy := identitySearchFields
+		params.QueryBy = &queryBy
+	}
+	if filterBy := filter.filterBy(); filterBy != "" {
+		params.FilterBy = &filterBy
+	}
+	if filter.Page > 0 {
+		params.Page = &filter.Page
+	}
+	if filter.PerPage > 0 {
+		params.PerPage = &filter.PerPage
+	}
+
+	result, resp, err := NewSearchService(s.client).SearchIdentities(params)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	page := &IdentityPage{Found: result.Found, Page: result.Page, Identities: make([]*IdentityResponse, 0, len(result.Hits))}
+	for i := range result.Hits {
+		page.Identities = append(page.Identities, &result.Hits[i].Document)
+	}
+	return page, resp, nil
+}
+
+func NewIdentityService(client ClientInterface, validators ...IdentityValidator) *IdentityService {
+	return &IdentityService{client: client, validators: validators}
 }
@@ -0,0 +1,236 @@
+package blnkgo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultMaxDocumentSize is the size limit applied to KYC documents when none is given
+const DefaultMaxDocumentSize int64 = 10 << 20
+
+var (
+	// ErrDocumentTooLarge is returned when a document exceeds the size limit
+	ErrDocumentTooLarge = errors.New("document exceeds the size limit")
+	// ErrUnsupportedDocumentType is returned when a document is not a PDF, JPEG or PNG file
+	ErrUnsupportedDocumentType = errors.New("unsupported document content type")
+	// ErrDocumentChecksumMismatch is returned when the server copy of a document differs from the local one
+	ErrDocumentChecksumMismatch = errors.New("document checksum mismatch")
+)
+
+// allowedDocumentTypes are the content types accepted for KYC documents
+var allowedDocumentTypes = map[string]bool{
+	"application/pdf": true,
+	"image/jpeg":      true,
+	"image/png":       true,
+}
+
+// DocumentType describes what a KYC document proves.
+type DocumentType string
+
+const (
+	DocumentPassport       DocumentType = "passport"
+	DocumentNationalID     DocumentType = "national_id"
+	DocumentDriversLicense DocumentType = "drivers_license"
+	DocumentProofOfAddress DocumentType = "proof_of_address"
+	DocumentOther          DocumentType = "other"
+)
+
+// UploadDocumentRequest describes a KYC document to attach to an identity. File is a file
+// path or an io.Reader, MaxSize defaults to DefaultMaxDocumentSize.
+type UploadDocumentRequest struct {
+	DocumentType DocumentType
+	File         interface{}
+	FileName     string
+	MaxSize      int64
+}
+
+// IdentityDocument is a KYC document stored against an identity.
+type IdentityDocument struct {
+	DocumentID   string       `json:"document_id"`
+	IdentityID   string       `json:"identity_id"`
+	DocumentType DocumentType `json:"document_type"`
+	FileName     string       `json:"file_name"`
+	ContentType  string       `json:"content_type"`
+	Size         int64        `json:"size"`
+	SHA256       string       `json:"sha256"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+// UploadDocument attaches a PDF, JPEG or PNG document to an identity. The content type is
+// detected from the file contents, and the SHA-256 checksum the server reports back must
+// match the one computed locally.
+func (s *IdentityService) UploadDocument(identityId string, body UploadDocumentRequest) (*IdentityDocument, *http.Response, error) {
+	if identityId == "" {
+		return nil, nil, fmt.Errorf("identityId is required")
+	}
+	if body.DocumentType == "" {
+		return nil, nil, fmt.Errorf("document type is required")
+	}
+	maxSize := body.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxDocumentSize
+	}
+
+	data, fileName, err := readDocument(body.File, body.FileName, maxSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	contentType := http.DetectContentType(data)
+	if !allowedDocumentTypes[contentType] {
+		return nil, nil, fmt.Errorf("%w: %s", ErrUnsupportedDocumentType, contentType)
+	}
+	checksum := documentChecksum(data)
+
+	u := fmt.Sprintf("identities/%s/documents", identityId)
+	req, err := s.client.NewFileUploadRequest(u, "file", bytes.NewReader(data), fileName, map[string]string{
+		"document_type": string(body.DocumentType),
+		"content_type":  contentType,
+		"sha256":        checksum,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	document := new(IdentityDocument)
+	resp, err := s.client.CallWithRetry(req, document)
+	if err != nil {
+		return nil, resp, err
+	}
+	if document.SHA256 != "" && document.SHA256 != checksum {
+		return document, resp, fmt.Errorf("%w: uploaded %s, server stored %s", ErrDocumentChecksumMismatch, checksum, document.SHA256)
+	}
+	return document, resp, nil
+}
+
+// ListDocuments lists the KYC documents attached to an identity
+func (s *IdentityService) ListDocuments(identityId string) ([]IdentityDocument, *http.Response, error) {
+	if identityId == "" {
+		return nil, nil, fmt.Errorf("identityId is required")
+	}
+	u := fmt.Sprintf("identities/%s/documents", identityId)
+	req, err := s.client.NewRequest(u, http.MethodGet, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var documents []IdentityDocument
+	resp, err := s.client.CallWithRetry(req, &documents)
+	if err != nil {
+		return nil, resp, err
+	}
+	return documents, resp, nil
+}
+
+// DownloadDocument writes the contents of a KYC document to w. Nothing is written unless
+// the downloaded bytes match the size and checksum recorded for the document.
+func (s *IdentityService) DownloadDocument(identityId, documentId string, w io.Writer) (*IdentityDocument, *http.Response, error) {
+	if identityId == "" || documentId == "" {
+		return nil, nil, fmt.Errorf("identityId and documentId are required")
+	}
+	u := fmt.Sprintf("identities/%s/documents/%s", identityId, documentId)
+	req, err := s.client.NewRequest(u, http.MethodGet, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	document := new(IdentityDocument)
+	resp, err := s.client.CallWithRetry(req, document)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	req, err = s.client.NewRequest(u+"/download", http.MethodGet, nil)
+	if err != nil {
+		return document, nil, err
+	}
+	limit := document.Size
+	if limit <= 0 {
+		limit = DefaultMaxDocumentSize
+	}
+	content := &limitedBuffer{limit: limit}
+	resp, err = s.client.CallWithRetry(req, content)
+	if err != nil {
+		return document, resp, err
+	}
+
+	if document.Size > 0 && int64(content.Len()) != document.Size {
+		return document, resp, fmt.Errorf("%w: expected %d bytes, downloaded %d", ErrDocumentChecksumMismatch, document.Size, content.Len())
+	}
+	if checksum := documentChecksum(content.Bytes()); document.SHA256 != "" && checksum != document.SHA256 {
+		return document, resp, fmt.Errorf("%w: expected %s, downloaded %s", ErrDocumentChecksumMismatch, document.SHA256, checksum)
+	}
+	if _, err := w.Write(content.Bytes()); err != nil {
+		return document, resp, err
+	}
+	return document, resp, nil
+}
+
+// readDocument reads a file path or reader, failing once maxSize is exceeded
+func readDocument(file interface{}, fileName string, maxSize int64) ([]byte, string, error) {
+	var reader io.Reader
+	switch v := file.(type) {
+	case string:
+		f, err := os.Open(v)
+		if err != nil {
+			return nil, "", err
+		}
+		defer f.Close()
+		reader = f
+		if fileName == "" {
+			fileName = filepath.Base(v)
+		}
+	case io.Reader:
+		reader = v
+		if fileName == "" {
+			fileName = "document"
+		}
+	default:
+		return nil, "", fmt.Errorf("unsupported file input type")
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, "", fmt.Errorf("%w of %d bytes", ErrDocumentTooLarge, maxSize)
+	}
+	if len(data) == 0 {
+		return nil, "", fmt.Errorf("document is empty")
+	}
+	return data, fileName, nil
+}
+
+func documentChecksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// limitedBuffer collects a raw response body and fails once limit bytes are exceeded. The
+// buffer is not embedded so io.Copy can not bypass Write through bytes.Buffer.ReadFrom.
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int64
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if int64(b.buf.Len()+len(p)) > b.limit {
+		return 0, fmt.Errorf("%w of %d bytes", ErrDocumentTooLarge, b.limit)
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Len() int {
+	return b.buf.Len()
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
@@ -0,0 +1,144 @@
+package blnkgo_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var pdfDocument = []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\n%%EOF\n")
+
+func checksumOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestIdentityService_UploadDocument(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	path := filepath.Join(t.TempDir(), "passport.pdf")
+	require.NoError(t, os.WriteFile(path, pdfDocument, 0o600))
+
+	uploadReq := &http.Request{Method: http.MethodPost}
+	mockClient.On("NewFileUploadRequest", "identities/idt-1/documents", "file", mock.Anything).Return(uploadReq, nil).Run(func(args mock.Arguments) {
+		content, err := io.ReadAll(args.Get(2).(io.Reader))
+		require.NoError(t, err)
+		assert.Equal(t, pdfDocument, content)
+	})
+	mockClient.On("CallWithRetry", uploadReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusCreated}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.IdentityDocument) = blnkgo.IdentityDocument{
+			DocumentID:  "doc-1",
+			FileName:    "passport.pdf",
+			ContentType: "application/pdf",
+			SHA256:      checksumOf(pdfDocument),
+		}
+	})
+
+	document, _, err := svc.UploadDocument("idt-1", blnkgo.UploadDocumentRequest{DocumentType: blnkgo.DocumentPassport, File: path})
+	require.NoError(t, err)
+	assert.Equal(t, "doc-1", document.DocumentID)
+	mockClient.AssertExpectations(t)
+}
+
+func TestIdentityService_UploadDocument_ChecksumMismatch(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	mockClient.On("NewFileUploadRequest", "identities/idt-1/documents", "file", mock.Anything).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusCreated}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.IdentityDocument) = blnkgo.IdentityDocument{DocumentID: "doc-1", SHA256: checksumOf([]byte("other"))}
+	})
+
+	_, _, err := svc.UploadDocument("idt-1", blnkgo.UploadDocumentRequest{DocumentType: blnkgo.DocumentPassport, File: bytes.NewReader(pdfDocument)})
+	assert.ErrorIs(t, err, blnkgo.ErrDocumentChecksumMismatch)
+}
+
+func TestIdentityService_UploadDocument_Rejected(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	_, _, err := svc.UploadDocument("idt-1", blnkgo.UploadDocumentRequest{
+		DocumentType: blnkgo.DocumentPassport,
+		File:         bytes.NewReader(pdfDocument),
+		MaxSize:      10,
+	})
+	assert.ErrorIs(t, err, blnkgo.ErrDocumentTooLarge)
+
+	_, _, err = svc.UploadDocument("idt-1", blnkgo.UploadDocumentRequest{
+		DocumentType: blnkgo.DocumentPassport,
+		File:         strings.NewReader("name,dob\njane,1990-01-01\n"),
+	})
+	assert.ErrorIs(t, err, blnkgo.ErrUnsupportedDocumentType)
+
+	_, _, err = svc.UploadDocument("idt-1", blnkgo.UploadDocumentRequest{File: bytes.NewReader(pdfDocument)})
+	assert.Error(t, err)
+
+	_, _, err = svc.UploadDocument("idt-1", blnkgo.UploadDocumentRequest{DocumentType: blnkgo.DocumentPassport, File: 42})
+	assert.Error(t, err)
+
+	mockClient.AssertNotCalled(t, "NewFileUploadRequest", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdentityService_ListDocuments(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	mockClient.On("NewRequest", "identities/idt-1/documents", http.MethodGet, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]blnkgo.IdentityDocument) = []blnkgo.IdentityDocument{{DocumentID: "doc-1"}, {DocumentID: "doc-2"}}
+	})
+
+	documents, _, err := svc.ListDocuments("idt-1")
+	require.NoError(t, err)
+	assert.Len(t, documents, 2)
+	mockClient.AssertExpectations(t)
+}
+
+func TestIdentityService_DownloadDocument(t *testing.T) {
+	served := pdfDocument
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/identities/idt-1/documents/doc-1":
+			_ = json.NewEncoder(w).Encode(blnkgo.IdentityDocument{
+				DocumentID:  "doc-1",
+				ContentType: "application/pdf",
+				Size:        int64(len(pdfDocument)),
+				SHA256:      checksumOf(pdfDocument),
+			})
+		case "/identities/idt-1/documents/doc-1/download":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write(served)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	var out bytes.Buffer
+	document, _, err := client.Identity.DownloadDocument("idt-1", "doc-1", &out)
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", document.ContentType)
+	assert.Equal(t, pdfDocument, out.Bytes())
+
+	//a corrupted download is not written
+	served = append([]byte{}, pdfDocument...)
+	served[0] = 'X'
+	out.Reset()
+	_, _, err = client.Identity.DownloadDocument("idt-1", "doc-1", &out)
+	assert.ErrorIs(t, err, blnkgo.ErrDocumentChecksumMismatch)
+	assert.Zero(t, out.Len())
+
+	//a download larger than the recorded size is cut off
+	served = append(append([]byte{}, pdfDocument...), pdfDocument...)
+	_, _, err = client.Identity.DownloadDocument("idt-1", "doc-1", &out)
+	assert.ErrorIs(t, err, blnkgo.ErrDocumentTooLarge)
+	assert.Zero(t, out.Len())
+}
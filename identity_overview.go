@@ -0,0 +1,226 @@
+package blnkgo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrPrecisionMismatch is reported by Overview when balances of one currency use precisions
+// that can not be converted into each other, so they can not be added into one total
+var ErrPrecisionMismatch = errors.New("balance precisions can not be combined")
+
+const (
+	overviewRecentTransactions = 20
+	overviewConcurrency        = 8
+)
+
+// OverviewOptions tunes Overview. RecentTransactions is how many of the latest
+// transactions are returned and Concurrency how many requests run at once.
+type OverviewOptions struct {
+	RecentTransactions int
+	Concurrency        int
+}
+
+// CurrencyTotal sums the balances of an identity held in one currency, in its smallest
+// unit at Precision.
+type CurrencyTotal struct {
+	Currency        string `json:"currency"`
+	Precision       int    `json:"precision"`
+	Balance         int    `json:"balance"`
+	CreditBalance   int    `json:"credit_balance"`
+	DebitBalance    int    `json:"debit_balance"`
+	InflightBalance int    `json:"inflight_balance"`
+	Balances        int    `json:"balances"`
+}
+
+// IdentityOverview is everything known about an identity gathered in one call. Monitors
+// holds every monitor registered on each balance keyed by balance id, the API has no
+// paused or disabled monitors so all of them are active.
+type IdentityOverview struct {
+	Identity           *IdentityResponse            `json:"identity"`
+	Balances           []LedgerBalance              `json:"balances"`
+	Totals             map[string]CurrencyTotal     `json:"totals"`
+	RecentTransactions []Transaction                `json:"recent_transactions"`
+	Monitors           map[string][]MonitorDataResp `json:"monitors"`
+}
+
+// Overview gathers an identity, its balances with their current amounts and totals per
+// currency, its recent transactions and the monitors on its balances.
+func (s *IdentityService) Overview(identityId string) (*IdentityOverview, error) {
+	return s.OverviewWithOptions(identityId, OverviewOptions{})
+}
+
+// OverviewWithOptions is Overview with a custom transaction count and concurrency. The
+// identity and its balance list are required, when later lookups fail the overview holds
+// whatever was gathered and the returned error joins every failure.
+func (s *IdentityService) OverviewWithOptions(identityId string, opts OverviewOptions) (*IdentityOverview, error) {
+	if identityId == "" {
+		return nil, fmt.Errorf("identityId is required")
+	}
+	if opts.RecentTransactions <= 0 {
+		opts.RecentTransactions = overviewRecentTransactions
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = overviewConcurrency
+	}
+
+	overview := &IdentityOverview{
+		Totals:   make(map[string]CurrencyTotal),
+		Monitors: make(map[string][]MonitorDataResp),
+	}
+
+	//the identity and its balance ids are fetched together, everything else needs the ids
+	var balanceIDs []string
+	var identityErr, searchErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		overview.Identity, _, identityErr = s.Get(identityId)
+	}()
+	go func() {
+		defer wg.Done()
+		filterBy := "identity_id:=" + identityId
+		balanceIDs, searchErr = NewSearchService(s.client).searchAllBalanceIDs(SearchParams{Q: "*", FilterBy: &filterBy})
+	}()
+	wg.Wait()
+	if err := errors.Join(identityErr, searchErr); err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	var errs []error
+	balances := make([]*LedgerBalance, len(balanceIDs))
+	monitors := make([][]MonitorDataResp, len(balanceIDs))
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}
+
+	sem := make(chan struct{}, opts.Concurrency)
+	run := func(task func()) {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			task()
+		}()
+	}
+
+	if len(balanceIDs) > 0 {
+		run(func() {
+			transactions, err := s.recentTransactions(balanceIDs, opts.RecentTransactions)
+			if err != nil {
+				fail(fmt.Errorf("recent transactions: %w", err))
+				return
+			}
+			overview.RecentTransactions = transactions
+		})
+	}
+	ledgerBalances := &LedgerBalanceService{client: s.client}
+	balanceMonitors := NewBalanceMonitorService(s.client)
+	for i, balanceID := range balanceIDs {
+		run(func() {
+			balance, _, err := ledgerBalances.Get(balanceID)
+			if err != nil {
+				fail(fmt.Errorf("balance %s: %w", balanceID, err))
+				return
+			}
+			balances[i] = balance
+		})
+		run(func() {
+			list, _, err := balanceMonitors.ListByBalance(balanceID)
+			if err != nil {
+				fail(fmt.Errorf("monitors for balance %s: %w", balanceID, err))
+				return
+			}
+			monitors[i] = list
+		})
+	}
+	wg.Wait()
+
+	for i, balance := range balances {
+		if balance != nil {
+			overview.Balances = append(overview.Balances, *balance)
+			if err := addCurrencyTotal(overview.Totals, *balance); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if len(monitors[i]) > 0 {
+			overview.Monitors[balanceIDs[i]] = monitors[i]
+		}
+	}
+
+	return overview, errors.Join(errs...)
+}
+
+// recentTransactions returns the latest transactions moving money in or out of balanceIDs
+func (s *IdentityService) recentTransactions(balanceIDs []string, limit int) ([]Transaction, error) {
+	ids := "[" + strings.Join(balanceIDs, ",") + "]"
+	filterBy := fmt.Sprintf("source:=%s || destination:=%s", ids, ids)
+	sortBy := "created_at:desc"
+	page := 1
+	result, _, err := NewSearchService(s.client).SearchTransactions(SearchParams{
+		Q:        "*",
+		FilterBy: &filterBy,
+		SortBy:   &sortBy,
+		Page:     &page,
+		PerPage:  &limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]Transaction, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		transactions = append(transactions, hit.Document)
+	}
+	//keep the order stable even if the server ignores sort_by
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].CreatedAt.After(transactions[j].CreatedAt)
+	})
+	if len(transactions) > limit {
+		transactions = transactions[:limit]
+	}
+	return transactions, nil
+}
+
+// addCurrencyTotal adds balance to the total of its currency, rescaling amounts when
+// balances of one currency were created with different precisions. Precisions that do not
+// divide each other can not be added exactly, such a balance is left out of the total.
+func addCurrencyTotal(totals map[string]CurrencyTotal, balance LedgerBalance) error {
+	precision := balance.Precision
+	if precision <= 0 {
+		precision = 1
+	}
+	total, ok := totals[balance.Currency]
+	if !ok {
+		total = CurrencyTotal{Currency: balance.Currency, Precision: precision}
+	}
+	if total.Precision%precision != 0 && precision%total.Precision != 0 {
+		return fmt.Errorf("%w: balance %s has precision %d, %s total has %d", ErrPrecisionMismatch, balance.BalanceID, precision, balance.Currency, total.Precision)
+	}
+
+	if precision > total.Precision {
+		scale := precision / total.Precision
+		total.Balance *= scale
+		total.CreditBalance *= scale
+		total.DebitBalance *= scale
+		total.InflightBalance *= scale
+		total.Precision = precision
+	}
+	scale := total.Precision / precision
+
+	total.Balance += balance.Balance * scale
+	total.CreditBalance += balance.CreditBalance * scale
+	total.DebitBalance += balance.DebitBalance * scale
+	total.InflightBalance += balance.InflightBalance * scale
+	total.Balances++
+	totals[balance.Currency] = total
+	return nil
+}
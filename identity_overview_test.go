@@ -0,0 +1,127 @@
+package blnkgo_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// overviewServer serves an identity with three balances, overrides replace balances by id
+func overviewServer(t *testing.T, failBalance string, overrides ...blnkgo.LedgerBalance) *blnkgo.Client {
+	balances := map[string]blnkgo.LedgerBalance{
+		"bal-usd-1": {BalanceID: "bal-usd-1", Currency: "USD", Precision: 100, Balance: 1050, CreditBalance: 2000, DebitBalance: 950},
+		"bal-usd-2": {BalanceID: "bal-usd-2", Currency: "USD", Precision: 1000, Balance: 2500, CreditBalance: 2500},
+		"bal-ngn":   {BalanceID: "bal-ngn", Currency: "NGN", Precision: 100, Balance: 700000, InflightBalance: 5000},
+	}
+	for _, balance := range overrides {
+		balances[balance.BalanceID] = balance
+	}
+	now := time.Now()
+
+	return newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/identities/idt-1":
+			_ = json.NewEncoder(w).Encode(blnkgo.IdentityResponse{IdentityId: "idt-1", Identity: blnkgo.Identity{FirstName: "Jane"}})
+		case r.URL.Path == "/search/balances":
+			var params blnkgo.SearchParams
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+			assert.Equal(t, "identity_id:=idt-1", *params.FilterBy)
+			_ = json.NewEncoder(w).Encode(blnkgo.SearchResponse{Found: 3, Hits: []blnkgo.SearchHit{
+				{Document: blnkgo.SearchDocument{BalanceID: "bal-usd-1"}},
+				{Document: blnkgo.SearchDocument{BalanceID: "bal-usd-2"}},
+				{Document: blnkgo.SearchDocument{BalanceID: "bal-ngn"}},
+			}})
+		case r.URL.Path == "/search/transactions":
+			var params blnkgo.SearchParams
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+			assert.Contains(t, *params.FilterBy, "source:=[bal-usd-1,bal-usd-2,bal-ngn]")
+			_ = json.NewEncoder(w).Encode(blnkgo.TransactionSearchResponse{Found: 2, Hits: []blnkgo.TransactionSearchHit{
+				{Document: blnkgo.Transaction{TransactionID: "tx-old", CreatedAt: now.Add(-time.Hour)}},
+				{Document: blnkgo.Transaction{TransactionID: "tx-new", CreatedAt: now}},
+			}})
+		case strings.HasPrefix(r.URL.Path, "/balances/"):
+			id := strings.TrimPrefix(r.URL.Path, "/balances/")
+			if id == failBalance {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(balances[id])
+		case strings.HasPrefix(r.URL.Path, "/balance-monitors/balances/"):
+			id := strings.TrimPrefix(r.URL.Path, "/balance-monitors/balances/")
+			var monitors []blnkgo.MonitorDataResp
+			if id == "bal-ngn" {
+				monitors = append(monitors, blnkgo.MonitorDataResp{MonitorID: "mon-1", MonitorData: blnkgo.MonitorData{BalanceID: id}})
+			}
+			_ = json.NewEncoder(w).Encode(monitors)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestIdentityService_Overview(t *testing.T) {
+	client := overviewServer(t, "")
+
+	overview, err := client.Identity.OverviewWithOptions("idt-1", blnkgo.OverviewOptions{RecentTransactions: 2, Concurrency: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Jane", overview.Identity.FirstName)
+	assert.Len(t, overview.Balances, 3)
+	assert.Equal(t, "bal-usd-1", overview.Balances[0].BalanceID)
+
+	//10.50 USD at precision 100 plus 2.500 USD at precision 1000
+	assert.Equal(t, blnkgo.CurrencyTotal{Currency: "USD", Precision: 1000, Balance: 13000, CreditBalance: 22500, DebitBalance: 9500, Balances: 2}, overview.Totals["USD"])
+	assert.Equal(t, blnkgo.CurrencyTotal{Currency: "NGN", Precision: 100, Balance: 700000, InflightBalance: 5000, Balances: 1}, overview.Totals["NGN"])
+
+	require.Len(t, overview.RecentTransactions, 2)
+	assert.Equal(t, "tx-new", overview.RecentTransactions[0].TransactionID)
+	assert.Len(t, overview.Monitors, 1)
+	assert.Equal(t, "mon-1", overview.Monitors["bal-ngn"][0].MonitorID)
+}
+
+func TestIdentityService_Overview_PartialFailure(t *testing.T) {
+	client := overviewServer(t, "bal-usd-2")
+
+	overview, err := client.Identity.Overview("idt-1")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "balance bal-usd-2")
+	require.NotNil(t, overview)
+	assert.Len(t, overview.Balances, 2)
+	assert.Equal(t, 1, overview.Totals["USD"].Balances)
+}
+
+func TestIdentityService_Overview_PrecisionMismatch(t *testing.T) {
+	client := overviewServer(t, "", blnkgo.LedgerBalance{BalanceID: "bal-usd-2", Currency: "USD", Precision: 30, Balance: 90})
+
+	overview, err := client.Identity.Overview("idt-1")
+
+	assert.ErrorIs(t, err, blnkgo.ErrPrecisionMismatch)
+	require.NotNil(t, overview)
+	assert.Len(t, overview.Balances, 3)
+	assert.Equal(t, blnkgo.CurrencyTotal{Currency: "USD", Precision: 100, Balance: 1050, CreditBalance: 2000, DebitBalance: 950, Balances: 1}, overview.Totals["USD"])
+}
+
+func TestIdentityService_Overview_MissingIdentity(t *testing.T) {
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/search/balances" {
+			_ = json.NewEncoder(w).Encode(blnkgo.SearchResponse{})
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := client.Identity.Overview("")
+	assert.Error(t, err)
+
+	overview, err := client.Identity.Overview("idt-unknown")
+	assert.Error(t, err)
+	assert.Nil(t, overview)
+}
@@ -1,6 +1,7 @@
 package blnkgo_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -313,3 +314,226 @@ func TestIdentityService_Create_EmptyPayload(t *testing.T) {
 	assert.Nil(t, resp)
 	assert.Nil(t, httpResp)
 }
+
+func TestIdentityService_Create_ValidationErrorListsAllFields(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	identity := blnkgo.Identity{
+		IdentityType: blnkgo.Individual,
+		FirstName:    "John",
+		EmailAddress: "not-an-email",
+		PhoneNumber:  "12ab",
+		Street:       "123 Main St",
+	}
+
+	resp, httpResp, err := svc.Create(identity)
+	assert.Nil(t, resp)
+	assert.Nil(t, httpResp)
+
+	var verr *blnkgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	for _, field := range []string{"last_name", "dob", "gender", "nationality", "email_address", "phone_number", "city", "country"} {
+		assert.True(t, verr.HasField(field), field)
+	}
+	assert.False(t, verr.HasField("first_name"))
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
+
+func TestIdentityService_Delete(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	mockClient.On("NewRequest", "identities/idt-1", http.MethodDelete, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, nil).Return(&http.Response{StatusCode: http.StatusNoContent}, nil)
+
+	httpResp, err := svc.Delete("idt-1")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, httpResp.StatusCode)
+	mockClient.AssertExpectations(t)
+
+	_, err = svc.Delete("")
+	assert.Error(t, err)
+}
+
+func TestIdentityService_ListWithFilter(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	filter := blnkgo.IdentityFilter{
+		IdentityType: blnkgo.Individual,
+		Category:     "customer",
+		Country:      "NG",
+		CreatedFrom:  from,
+		CreatedTo:    to,
+		Page:         2,
+		PerPage:      20,
+	}
+
+	mockClient.On("NewRequest", "search/identities", http.MethodPost, mock.MatchedBy(func(p blnkgo.SearchParams) bool {
+		return p.Q == "*" && p.QueryBy == nil &&
+			*p.FilterBy == fmt.Sprintf("identity_type:=individual && category:=customer && country:=NG && created_at:>=%d && created_at:<=%d", from.Unix(), to.Unix()) &&
+			*p.SortBy == "created_at:desc" && *p.Page == 2 && *p.PerPage == 20
+	})).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		//the index holds created_at as the Unix seconds the filter ranges over
+		payload := `{"found":21,"page":2,"hits":[{"document":{"identity_id":"idt-21","identity_type":"individual","created_at":1705311000}}]}`
+		assert.NoError(t, json.Unmarshal([]byte(payload), args.Get(1)))
+	})
+
+	page, _, err := svc.ListWithFilter(filter)
+	assert.NoError(t, err)
+	assert.Equal(t, 21, page.Found)
+	assert.Equal(t, 2, page.Page)
+	assert.Len(t, page.Identities, 1)
+	assert.Equal(t, "idt-21", page.Identities[0].IdentityId)
+	assert.Equal(t, "2024-01-15T09:30:00Z", page.Identities[0].CreatedAt)
+	mockClient.AssertExpectations(t)
+}
+
+func TestIdentityService_Search(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	mockClient.On("NewRequest", "search/identities", http.MethodPost, mock.MatchedBy(func(p blnkgo.SearchParams) bool {
+		return p.Q == "jane@example.com" && p.QueryBy != nil && p.FilterBy == nil
+	})).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		resp := args.Get(1).(*blnkgo.IdentitySearchResponse)
+		resp.Found = 1
+		resp.Hits = []blnkgo.IdentitySearchHit{{Document: blnkgo.IdentityResponse{IdentityId: "idt-1", Identity: blnkgo.Identity{EmailAddress: "jane@example.com"}}}}
+	})
+
+	page, _, err := svc.Search("jane@example.com", blnkgo.IdentityFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, "jane@example.com", page.Identities[0].EmailAddress)
+	mockClient.AssertExpectations(t)
+
+	_, _, err = svc.Search("", blnkgo.IdentityFilter{})
+	assert.Error(t, err)
+	_, _, err = svc.Search("*", blnkgo.IdentityFilter{CreatedFrom: time.Now(), CreatedTo: time.Now().Add(-time.Hour)})
+	assert.Error(t, err)
+}
+
+func TestIdentityValidators(t *testing.T) {
+	adult := time.Now().AddDate(-30, 0, 0)
+	minor := time.Now().AddDate(-17, 0, 0)
+	future := time.Now().AddDate(0, 0, 1)
+
+	tests := []struct {
+		name      string
+		validator blnkgo.IdentityValidator
+		identity  blnkgo.Identity
+		field     string
+	}{
+		{"valid email", blnkgo.EmailValidator(), blnkgo.Identity{EmailAddress: "jane@example.com"}, ""},
+		{"email with display name", blnkgo.EmailValidator(), blnkgo.Identity{EmailAddress: "Jane <jane@example.com>"}, "email_address"},
+		{"email without dotted domain", blnkgo.EmailValidator(), blnkgo.Identity{EmailAddress: "jane@localhost"}, "email_address"},
+		{"valid e164", blnkgo.E164PhoneValidator(), blnkgo.Identity{PhoneNumber: "+2348012345678"}, ""},
+		{"e164 without plus", blnkgo.E164PhoneValidator(), blnkgo.Identity{PhoneNumber: "2348012345678"}, "phone_number"},
+		{"e164 leading zero", blnkgo.E164PhoneValidator(), blnkgo.Identity{PhoneNumber: "+0348012345678"}, "phone_number"},
+		{"valid iso country", blnkgo.ISOCountryValidator(), blnkgo.Identity{Country: "NG", Nationality: "GB"}, ""},
+		{"alpha-3 country", blnkgo.ISOCountryValidator(), blnkgo.Identity{Country: "USA"}, "country"},
+		{"nationality name", blnkgo.ISOCountryValidator(), blnkgo.Identity{Nationality: "Nigerian"}, "nationality"},
+		{"adult", blnkgo.MinAgeValidator(18), blnkgo.Identity{IdentityType: blnkgo.Individual, DOB: &adult}, ""},
+		{"minor", blnkgo.MinAgeValidator(18), blnkgo.Identity{IdentityType: blnkgo.Individual, DOB: &minor}, "dob"},
+		{"born in the future", blnkgo.MinAgeValidator(18), blnkgo.Identity{DOB: &future}, "dob"},
+		{"organization age ignored", blnkgo.MinAgeValidator(18), blnkgo.Identity{IdentityType: blnkgo.Organization, DOB: &minor}, ""},
+		{"valid us zip", blnkgo.PostcodeValidator(nil), blnkgo.Identity{Country: "US", PostCode: "90001-1234"}, ""},
+		{"invalid gb postcode", blnkgo.PostcodeValidator(nil), blnkgo.Identity{Country: "GB", PostCode: "12345"}, "post_code"},
+		{"unknown country not checked", blnkgo.PostcodeValidator(nil), blnkgo.Identity{Country: "AQ", PostCode: "anything"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verr := &blnkgo.ValidationError{}
+			tt.validator.ValidateIdentity(tt.identity, verr)
+			if tt.field == "" {
+				assert.False(t, verr.HasErrors(), "%v", verr)
+				return
+			}
+			assert.True(t, verr.HasField(tt.field), "%v", verr)
+		})
+	}
+}
+
+func TestIdentityService_CreateWithValidators(t *testing.T) {
+	mockClient := &MockClient{}
+	svc := blnkgo.NewIdentityService(mockClient, blnkgo.ISOCountryValidator(), blnkgo.E164PhoneValidator())
+	dob := time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, _, err := svc.Create(blnkgo.Identity{
+		IdentityType: blnkgo.Individual,
+		FirstName:    "John",
+		LastName:     "Doe",
+		Gender:       "male",
+		DOB:          &dob,
+		Nationality:  "Nigerian",
+		Country:      "NG",
+		PhoneNumber:  "08012345678",
+	})
+
+	var verr *blnkgo.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.True(t, verr.HasField("nationality"))
+	assert.True(t, verr.HasField("phone_number"))
+	assert.False(t, verr.HasField("country"))
+	mockClient.AssertNotCalled(t, "NewRequest", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdentityService_Update_Validation(t *testing.T) {
+	mockClient := &MockClient{}
+	svc := blnkgo.NewIdentityService(mockClient, blnkgo.PostcodeValidator(nil))
+
+	_, _, err := svc.Update("idt-1", &blnkgo.Identity{IdentityType: "robot", EmailAddress: "nope", Country: "US", PostCode: "ABC"})
+	var verr *blnkgo.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.True(t, verr.HasField("identity_type"))
+	assert.True(t, verr.HasField("email_address"))
+	assert.True(t, verr.HasField("post_code"))
+
+	_, _, err = svc.Update("idt-1", nil)
+	assert.Error(t, err)
+	mockClient.AssertNotCalled(t, "NewRequest", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdentityService_Update_TokenizedAndPartialFields(t *testing.T) {
+	mockClient := &MockClient{}
+	svc := blnkgo.NewIdentityService(mockClient, blnkgo.E164PhoneValidator(), blnkgo.EmailValidator(), blnkgo.PostcodeValidator(nil))
+
+	//tokens sent back from a tokenized identity and a street without the rest of the address pass
+	identity := &blnkgo.Identity{
+		EmailAddress: "tok_8f2a91",
+		PhoneNumber:  "tok_11b0c4",
+		PostCode:     "tok_77d1e2",
+		Country:      "US",
+		Street:       "456 Oak St",
+		MetaData: map[string]interface{}{
+			"tokenized_fields": map[string]interface{}{"EmailAddress": true, "PhoneNumber": true, "PostCode": true},
+		},
+	}
+	mockClient.On("NewRequest", "identities/idt-1", http.MethodPut, identity).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil)
+
+	_, _, err := svc.Update("idt-1", identity)
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+
+	//fields that are not tokenized are still checked
+	err = blnkgo.ValidateUpdateIdentity(blnkgo.Identity{
+		PhoneNumber: "tok_11b0c4",
+		MetaData:    map[string]interface{}{"tokenized_fields": []interface{}{"EmailAddress"}},
+	}, blnkgo.E164PhoneValidator())
+	var verr *blnkgo.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.True(t, verr.HasField("phone_number"))
+}
+
+func TestClient_WithIdentityValidators(t *testing.T) {
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("invalid identity must not reach the server")
+	}, blnkgo.WithIdentityValidators(blnkgo.E164PhoneValidator()))
+
+	_, _, err := client.Identity.Update("idt-1", &blnkgo.Identity{PhoneNumber: "1234567890"})
+	var verr *blnkgo.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.True(t, verr.HasField("phone_number"))
+}
@@ -0,0 +1,197 @@
+package blnkgo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// PIIField names an Identity field that the server can tokenize.
+type PIIField string
+
+const (
+	PIIFieldFirstName    PIIField = "FirstName"
+	PIIFieldLastName     PIIField = "LastName"
+	PIIFieldOtherNames   PIIField = "OtherNames"
+	PIIFieldEmailAddress PIIField = "EmailAddress"
+	PIIFieldPhoneNumber  PIIField = "PhoneNumber"
+	PIIFieldStreet       PIIField = "Street"
+	PIIFieldPostCode     PIIField = "PostCode"
+)
+
+// tokenizedFieldsKey is the meta_data entry in which the server records tokenized fields
+const tokenizedFieldsKey = "tokenized_fields"
+
+// ErrDetokenizeForbidden is returned when the credentials in use may not read tokenized values
+var ErrDetokenizeForbidden = errors.New("not authorized to detokenize identity fields")
+
+// AllPIIFields returns every field that can be tokenized
+func AllPIIFields() []PIIField {
+	return []PIIField{
+		PIIFieldFirstName,
+		PIIFieldLastName,
+		PIIFieldOtherNames,
+		PIIFieldEmailAddress,
+		PIIFieldPhoneNumber,
+		PIIFieldStreet,
+		PIIFieldPostCode,
+	}
+}
+
+type tokenizeRequest struct {
+	Fields []PIIField `json:"fields"`
+}
+
+type detokenizeResponse struct {
+	Fields map[PIIField]string `json:"fields"`
+}
+
+type tokenizedFieldsResponse struct {
+	TokenizedFields []PIIField `json:"tokenized_fields"`
+}
+
+// Tokenize replaces the given fields of an identity with tokens on the server, every PII
+// field is tokenized when no fields are given.
+func (s *IdentityService) Tokenize(identityId string, fields ...PIIField) (*http.Response, error) {
+	fields, err := piiFieldsOrAll(identityId, fields)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireFeature(s.client, FeatureIdentityTokenization); err != nil {
+		return nil, err
+	}
+
+	u := fmt.Sprintf("identities/%s/tokenize", identityId)
+	req, err := s.client.NewRequest(u, http.MethodPost, tokenizeRequest{Fields: fields})
+	if err != nil {
+		return nil, err
+	}
+	return s.client.CallWithRetry(req, nil)
+}
+
+// Detokenize returns the original values of the given tokenized fields, or of every PII
+// field when no fields are given. The stored identity stays tokenized. The server only
+// allows this for credentials with detokenize access, a refusal is reported as
+// ErrDetokenizeForbidden.
+func (s *IdentityService) Detokenize(identityId string, fields ...PIIField) (map[PIIField]string, *http.Response, error) {
+	fields, err := piiFieldsOrAll(identityId, fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := requireFeature(s.client, FeatureIdentityTokenization); err != nil {
+		return nil, nil, err
+	}
+
+	u := fmt.Sprintf("identities/%s/detokenize", identityId)
+	req, err := s.client.NewRequest(u, http.MethodPost, tokenizeRequest{Fields: fields})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(detokenizeResponse)
+	resp, err := s.client.CallWithRetry(req, result)
+	if err != nil {
+		var apiErr *ApiErrorResponse
+		if errors.As(err, &apiErr) && (apiErr.Status == http.StatusUnauthorized || apiErr.Status == http.StatusForbidden) {
+			return nil, resp, fmt.Errorf("%w: %w", ErrDetokenizeForbidden, err)
+		}
+		return nil, resp, err
+	}
+	return result.Fields, resp, nil
+}
+
+// ListTokenizedFields returns the fields of an identity that are currently tokenized
+func (s *IdentityService) ListTokenizedFields(identityId string) ([]PIIField, *http.Response, error) {
+	if identityId == "" {
+		return nil, nil, fmt.Errorf("identityId is required")
+	}
+	if err := requireFeature(s.client, FeatureIdentityTokenization); err != nil {
+		return nil, nil, err
+	}
+
+	u := fmt.Sprintf("identities/%s/tokenized-fields", identityId)
+	req, err := s.client.NewRequest(u, http.MethodGet, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := new(tokenizedFieldsResponse)
+	resp, err := s.client.CallWithRetry(req, result)
+	if err != nil {
+		return nil, resp, err
+	}
+	return result.TokenizedFields, resp, nil
+}
+
+// TokenizedFields returns the fields the server has marked as tokenized in the identity
+// meta data, so callers know which values are tokens rather than real data.
+func (i Identity) TokenizedFields() []PIIField {
+	var fields []PIIField
+	switch recorded := i.MetaData[tokenizedFieldsKey].(type) {
+	case map[string]interface{}:
+		for name, tokenized := range recorded {
+			if ok, _ := tokenized.(bool); ok {
+				fields = append(fields, PIIField(name))
+			}
+		}
+	case map[string]bool:
+		for name, tokenized := range recorded {
+			if tokenized {
+				fields = append(fields, PIIField(name))
+			}
+		}
+	case []interface{}:
+		for _, name := range recorded {
+			if s, ok := name.(string); ok {
+				fields = append(fields, PIIField(s))
+			}
+		}
+	}
+	sort.Slice(fields, func(a, b int) bool { return fields[a] < fields[b] })
+	return fields
+}
+
+// IsTokenized reports whether field holds a token instead of the real value
+func (i Identity) IsTokenized(field PIIField) bool {
+	for _, f := range i.TokenizedFields() {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// piiFieldsOrAll validates fields and defaults them to every PII field
+func piiFieldsOrAll(identityId string, fields []PIIField) ([]PIIField, error) {
+	verr := &ValidationError{}
+	if identityId == "" {
+		verr.Add("identity_id", ValidationCodeRequired, "identityId is required")
+	}
+	if len(fields) == 0 {
+		fields = AllPIIFields()
+	}
+
+	seen := make(map[PIIField]bool)
+	unique := make([]PIIField, 0, len(fields))
+	for i, field := range fields {
+		if !isPIIField(field) {
+			verr.Add(fmt.Sprintf("fields[%d]", i), ValidationCodeInvalid, fmt.Sprintf("%s can not be tokenized", field))
+			continue
+		}
+		if !seen[field] {
+			seen[field] = true
+			unique = append(unique, field)
+		}
+	}
+	return unique, verr.ErrOrNil()
+}
+
+func isPIIField(field PIIField) bool {
+	for _, f := range AllPIIFields() {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package blnkgo_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// jsonBody encodes a request body so unexported body types can be matched
+func jsonBody(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// decodeInto fills a response value the way CallWithRetry would
+func decodeInto(body string, v interface{}) error {
+	return json.Unmarshal([]byte(body), v)
+}
+
+func TestIdentityService_Tokenize(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	body := mock.MatchedBy(func(v interface{}) bool {
+		b, _ := jsonBody(v)
+		return b == `{"fields":["EmailAddress","PhoneNumber"]}`
+	})
+	mockClient.On("NewRequest", "identities/idt-1/tokenize", http.MethodPost, body).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, nil).Return(&http.Response{StatusCode: http.StatusOK}, nil)
+
+	_, err := svc.Tokenize("idt-1", blnkgo.PIIFieldEmailAddress, blnkgo.PIIFieldPhoneNumber, blnkgo.PIIFieldEmailAddress)
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestIdentityService_Tokenize_AllFields(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	body := mock.MatchedBy(func(v interface{}) bool {
+		b, _ := jsonBody(v)
+		return b == `{"fields":["FirstName","LastName","OtherNames","EmailAddress","PhoneNumber","Street","PostCode"]}`
+	})
+	mockClient.On("NewRequest", "identities/idt-1/tokenize", http.MethodPost, body).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, nil).Return(&http.Response{StatusCode: http.StatusOK}, nil)
+
+	_, err := svc.Tokenize("idt-1")
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestIdentityService_Tokenize_Invalid(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	_, err := svc.Tokenize("", "Category")
+	var verr *blnkgo.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.True(t, verr.HasField("identity_id"))
+	assert.True(t, verr.HasField("fields[0]"))
+	mockClient.AssertNotCalled(t, "NewRequest", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdentityService_Detokenize(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	mockClient.On("NewRequest", "identities/idt-1/detokenize", http.MethodPost, mock.Anything).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		assert.NoError(t, decodeInto(`{"fields":{"EmailAddress":"jane@example.com"}}`, args.Get(1)))
+	})
+
+	values, _, err := svc.Detokenize("idt-1", blnkgo.PIIFieldEmailAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, map[blnkgo.PIIField]string{blnkgo.PIIFieldEmailAddress: "jane@example.com"}, values)
+	mockClient.AssertExpectations(t)
+}
+
+func TestIdentityService_Detokenize_Forbidden(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	apiErr := &blnkgo.ApiErrorResponse{Status: http.StatusForbidden, Message: "403 Forbidden"}
+	mockClient.On("NewRequest", "identities/idt-1/detokenize", http.MethodPost, mock.Anything).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusForbidden}, apiErr)
+
+	_, _, err := svc.Detokenize("idt-1")
+	assert.ErrorIs(t, err, blnkgo.ErrDetokenizeForbidden)
+	var target *blnkgo.ApiErrorResponse
+	assert.True(t, errors.As(err, &target))
+}
+
+func TestIdentityService_ListTokenizedFields(t *testing.T) {
+	mockClient, svc := setupIdentityService()
+
+	mockClient.On("NewRequest", "identities/idt-1/tokenized-fields", http.MethodGet, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		assert.NoError(t, decodeInto(`{"tokenized_fields":["FirstName","LastName"]}`, args.Get(1)))
+	})
+
+	fields, _, err := svc.ListTokenizedFields("idt-1")
+	assert.NoError(t, err)
+	assert.Equal(t, []blnkgo.PIIField{blnkgo.PIIFieldFirstName, blnkgo.PIIFieldLastName}, fields)
+	mockClient.AssertExpectations(t)
+}
+
+func TestIdentity_TokenizedFields(t *testing.T) {
+	identity := blnkgo.Identity{MetaData: map[string]interface{}{
+		"tokenized_fields": map[string]interface{}{"PhoneNumber": true, "EmailAddress": true, "FirstName": false},
+	}}
+
+	assert.Equal(t, []blnkgo.PIIField{blnkgo.PIIFieldEmailAddress, blnkgo.PIIFieldPhoneNumber}, identity.TokenizedFields())
+	assert.True(t, identity.IsTokenized(blnkgo.PIIFieldPhoneNumber))
+	assert.False(t, identity.IsTokenized(blnkgo.PIIFieldFirstName))
+	assert.Empty(t, blnkgo.Identity{}.TokenizedFields())
+}
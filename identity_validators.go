@@ -0,0 +1,130 @@
+package blnkgo
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// IdentityValidator checks one rule on an identity and records violations in verr.
+// Validators only look at fields that are set, so they apply to creates and partial updates alike.
+type IdentityValidator interface {
+	ValidateIdentity(identity Identity, verr *ValidationError)
+}
+
+// IdentityValidatorFunc adapts a function to an IdentityValidator
+type IdentityValidatorFunc func(identity Identity, verr *ValidationError)
+
+func (f IdentityValidatorFunc) ValidateIdentity(identity Identity, verr *ValidationError) {
+	f(identity, verr)
+}
+
+var e164Regex = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// iso3166Alpha2 holds the officially assigned ISO 3166-1 alpha-2 country codes
+var iso3166Alpha2 = toSet(strings.Fields(`
+	AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ
+	BR BS BT BV BW BY BZ CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM
+	DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS
+	GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE JM JO JP KE KG KH KI KM KN
+	KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO MP MQ
+	MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM
+	PN PR PS PT PW PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV
+	SX SY SZ TC TD TF TG TH TJ TK TL TM TN TO TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI
+	VN VU WF WS YE YT ZA ZM ZW`))
+
+// DefaultPostcodePatterns are the postcode formats checked by PostcodeValidator when none are given,
+// keyed by ISO 3166-1 alpha-2 country code.
+var DefaultPostcodePatterns = map[string]*regexp.Regexp{
+	"US": regexp.MustCompile(`^[0-9]{5}(-[0-9]{4})?$`),
+	"CA": regexp.MustCompile(`^[A-Za-z][0-9][A-Za-z] ?[0-9][A-Za-z][0-9]$`),
+	"GB": regexp.MustCompile(`^[A-Za-z]{1,2}[0-9][A-Za-z0-9]? ?[0-9][A-Za-z]{2}$`),
+	"DE": regexp.MustCompile(`^[0-9]{5}$`),
+	"FR": regexp.MustCompile(`^[0-9]{5}$`),
+	"NL": regexp.MustCompile(`^[0-9]{4} ?[A-Za-z]{2}$`),
+	"IN": regexp.MustCompile(`^[1-9][0-9]{5}$`),
+	"NG": regexp.MustCompile(`^[0-9]{6}$`),
+	"KE": regexp.MustCompile(`^[0-9]{5}$`),
+	"ZA": regexp.MustCompile(`^[0-9]{4}$`),
+	"AU": regexp.MustCompile(`^[0-9]{4}$`),
+	"BR": regexp.MustCompile(`^[0-9]{5}-?[0-9]{3}$`),
+	"JP": regexp.MustCompile(`^[0-9]{3}-?[0-9]{4}$`),
+}
+
+// EmailValidator rejects email addresses that are not a bare RFC 5322 address with a dotted domain
+func EmailValidator() IdentityValidator {
+	return IdentityValidatorFunc(func(identity Identity, verr *ValidationError) {
+		if identity.EmailAddress == "" {
+			return
+		}
+		addr, err := mail.ParseAddress(identity.EmailAddress)
+		if err != nil || addr.Address != identity.EmailAddress || !strings.Contains(addr.Address[strings.LastIndex(addr.Address, "@"):], ".") {
+			verr.Add("email_address", ValidationCodeInvalid, "email address is not valid")
+		}
+	})
+}
+
+// E164PhoneValidator requires phone numbers in E.164 form, such as +2348012345678
+func E164PhoneValidator() IdentityValidator {
+	return IdentityValidatorFunc(func(identity Identity, verr *ValidationError) {
+		if identity.PhoneNumber != "" && !e164Regex.MatchString(identity.PhoneNumber) {
+			verr.Add("phone_number", ValidationCodeInvalid, "phone number must be in E.164 format such as +2348012345678")
+		}
+	})
+}
+
+// ISOCountryValidator requires Country and Nationality to be ISO 3166-1 alpha-2 codes such as NG
+func ISOCountryValidator() IdentityValidator {
+	return IdentityValidatorFunc(func(identity Identity, verr *ValidationError) {
+		if identity.Country != "" && !iso3166Alpha2[identity.Country] {
+			verr.Add("country", ValidationCodeInvalid, "country must be an ISO 3166-1 alpha-2 code such as NG")
+		}
+		if identity.Nationality != "" && !iso3166Alpha2[identity.Nationality] {
+			verr.Add("nationality", ValidationCodeInvalid, "nationality must be an ISO 3166-1 alpha-2 code such as NG")
+		}
+	})
+}
+
+// MinAgeValidator requires individuals with a DOB to be at least years old
+func MinAgeValidator(years int) IdentityValidator {
+	return IdentityValidatorFunc(func(identity Identity, verr *ValidationError) {
+		if identity.DOB == nil || identity.IdentityType == Organization {
+			return
+		}
+		today := time.Now()
+		if identity.DOB.After(today) {
+			verr.Add("dob", ValidationCodeInvalid, "date of birth can not be in the future")
+			return
+		}
+		if identity.DOB.AddDate(years, 0, 0).After(today) {
+			verr.Add("dob", ValidationCodeInvalid, fmt.Sprintf("identity must be at least %d years old", years))
+		}
+	})
+}
+
+// PostcodeValidator checks PostCode against the pattern for Country. Countries without a
+// pattern are not checked, nil patterns fall back to DefaultPostcodePatterns.
+func PostcodeValidator(patterns map[string]*regexp.Regexp) IdentityValidator {
+	if patterns == nil {
+		patterns = DefaultPostcodePatterns
+	}
+	return IdentityValidatorFunc(func(identity Identity, verr *ValidationError) {
+		if identity.PostCode == "" {
+			return
+		}
+		pattern, ok := patterns[strings.ToUpper(identity.Country)]
+		if ok && !pattern.MatchString(identity.PostCode) {
+			verr.Add("post_code", ValidationCodeInvalid, fmt.Sprintf("post code is not valid for %s", identity.Country))
+		}
+	})
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
@@ -0,0 +1,142 @@
+package blnkgo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrInflightOverCommit is returned when a commit exceeds the amount still held by an inflight transaction
+	ErrInflightOverCommit = errors.New("commit amount exceeds the remaining inflight amount")
+	// ErrNotInflight is returned when a commit or void targets a transaction that is not inflight
+	ErrNotInflight = errors.New("transaction is not inflight")
+)
+
+// InflightSummary describes how much of an inflight transaction has been committed.
+type InflightSummary struct {
+	TransactionID    string  `json:"transaction_id"`
+	Currency         string  `json:"currency"`
+	Precision        int64   `json:"precision"`
+	Authorized       float64 `json:"authorized"`
+	Committed        float64 `json:"committed"`
+	Remaining        float64 `json:"remaining"`
+	PreciseRemaining int64   `json:"precise_remaining"`
+	Voided           bool    `json:"voided"`
+	// Commits holds the transactions created by previous commits
+	Commits []Transaction `json:"commits,omitempty"`
+}
+
+// GetInflightSummary reads an inflight transaction and the commits made against it,
+// and returns the amount that can still be committed.
+func (s *TransactionService) GetInflightSummary(transactionID string) (*InflightSummary, error) {
+	if transactionID == "" {
+		return nil, fmt.Errorf("transactionID is required")
+	}
+
+	inflight, _, err := s.Get(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if inflight.Status != PryTransactionStatusInFlight {
+		return nil, fmt.Errorf("%w: %s has status %s", ErrNotInflight, transactionID, inflight.Status)
+	}
+
+	children, err := NewSearchService(s.client).searchAllTransactions("parent_transaction:=" + transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	precision := inflight.Precision
+	if precision <= 0 {
+		precision = 1
+	}
+	summary := &InflightSummary{
+		TransactionID: transactionID,
+		Currency:      inflight.Currency,
+		Precision:     precision,
+		Authorized:    inflight.Amount,
+	}
+
+	authorized := toPreciseAmount(inflight.ParentTransaction)
+	var committed int64
+	for _, child := range children {
+		switch child.Status {
+		case PryTransactionStatusVoid:
+			summary.Voided = true
+		case PryTransactionStatusRejected:
+			//rejected commits never moved funds
+		default:
+			committed += toPreciseAmount(child.ParentTransaction)
+			summary.Commits = append(summary.Commits, child)
+		}
+	}
+
+	remaining := authorized - committed
+	if remaining < 0 || summary.Voided {
+		remaining = 0
+	}
+	summary.Committed = float64(committed) / float64(precision)
+	summary.Remaining = float64(remaining) / float64(precision)
+	summary.PreciseRemaining = remaining
+
+	return summary, nil
+}
+
+// CommitInflight commits amount from an inflight transaction. An amount of zero commits
+// whatever is left, a smaller amount performs a partial commit that can be followed by
+// further commits until the authorized amount is exhausted.
+func (s *TransactionService) CommitInflight(transactionID string, amount float64) (*Transaction, error) {
+	if amount < 0 {
+		return nil, fmt.Errorf("amount can not be negative")
+	}
+
+	summary, err := s.GetInflightSummary(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if summary.Voided {
+		return nil, fmt.Errorf("%w: %s has been voided", ErrNotInflight, transactionID)
+	}
+	if summary.PreciseRemaining == 0 {
+		return nil, fmt.Errorf("%w: nothing left to commit on %s", ErrInflightOverCommit, transactionID)
+	}
+	if amount > 0 && int64(math.Round(amount*float64(summary.Precision))) > summary.PreciseRemaining {
+		return nil, fmt.Errorf("%w: requested %v, remaining %v", ErrInflightOverCommit, amount, summary.Remaining)
+	}
+
+	transaction, _, err := s.Update(transactionID, UpdateStatus{Status: InflightStatusCommit, Amount: amount})
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// VoidInflight releases whatever is left of an inflight transaction.
+func (s *TransactionService) VoidInflight(transactionID string) (*Transaction, error) {
+	summary, err := s.GetInflightSummary(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	if summary.Voided {
+		return nil, fmt.Errorf("%w: %s has already been voided", ErrNotInflight, transactionID)
+	}
+
+	transaction, _, err := s.Update(transactionID, UpdateStatus{Status: InflightStatusVoid})
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// toPreciseAmount returns the amount of t in its smallest unit
+func toPreciseAmount(t ParentTransaction) int64 {
+	if t.PreciseAmount != 0 {
+		return t.PreciseAmount
+	}
+	precision := t.Precision
+	if precision <= 0 {
+		precision = 1
+	}
+	return int64(math.Round(t.Amount * float64(precision)))
+}
@@ -0,0 +1,128 @@
+package blnkgo_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// setupInflightMocks stubs the Get and search calls used to compute an inflight summary
+func setupInflightMocks(m *MockClient, inflight blnkgo.Transaction, children []blnkgo.Transaction) {
+	getReq := &http.Request{Method: http.MethodGet}
+	searchReq := &http.Request{Method: http.MethodPost}
+
+	m.On("NewRequest", "transactions/"+inflight.TransactionID, http.MethodGet, nil).Return(getReq, nil)
+	m.On("NewRequest", "search/transactions", http.MethodPost, mock.Anything).Return(searchReq, nil)
+	m.On("CallWithRetry", getReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.Transaction) = inflight
+	})
+	m.On("CallWithRetry", searchReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		resp := args.Get(1).(*blnkgo.TransactionSearchResponse)
+		resp.Found = len(children)
+		for _, c := range children {
+			resp.Hits = append(resp.Hits, blnkgo.TransactionSearchHit{Document: c})
+		}
+	})
+}
+
+func inflightTransaction(amount float64) blnkgo.Transaction {
+	return blnkgo.Transaction{
+		TransactionID: "tx-1",
+		ParentTransaction: blnkgo.ParentTransaction{
+			Amount:    amount,
+			Precision: 100,
+			Currency:  "USD",
+			Status:    blnkgo.PryTransactionStatusInFlight,
+		},
+	}
+}
+
+func commitTransaction(amount float64, status blnkgo.PryTransactionStatus) blnkgo.Transaction {
+	return blnkgo.Transaction{
+		ParentTransaction: blnkgo.ParentTransaction{Amount: amount, Precision: 100, Status: status},
+	}
+}
+
+func TestTransactionService_GetInflightSummary(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupInflightMocks(mockClient, inflightTransaction(100), []blnkgo.Transaction{
+		commitTransaction(30, blnkgo.PryTransactionStatusApplied),
+		commitTransaction(20.5, blnkgo.PryTransactionStatusApplied),
+		commitTransaction(10, blnkgo.PryTransactionStatusRejected),
+	})
+
+	summary, err := svc.GetInflightSummary("tx-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 100.0, summary.Authorized)
+	assert.Equal(t, 50.5, summary.Committed)
+	assert.Equal(t, 49.5, summary.Remaining)
+	assert.Equal(t, int64(4950), summary.PreciseRemaining)
+	assert.Len(t, summary.Commits, 2)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTransactionService_GetInflightSummary_NotInflight(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	tx := inflightTransaction(100)
+	tx.Status = blnkgo.PryTransactionStatusApplied
+	mockClient.On("NewRequest", "transactions/tx-1", http.MethodGet, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.Transaction) = tx
+	})
+
+	summary, err := svc.GetInflightSummary("tx-1")
+	assert.Nil(t, summary)
+	assert.True(t, errors.Is(err, blnkgo.ErrNotInflight))
+}
+
+func TestTransactionService_CommitInflight_Partial(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupInflightMocks(mockClient, inflightTransaction(100), nil)
+
+	body := blnkgo.UpdateStatus{Status: blnkgo.InflightStatusCommit, Amount: 87}
+	putReq := &http.Request{Method: http.MethodPut}
+	mockClient.On("NewRequest", "transactions/inflight/tx-1", http.MethodPut, body).Return(putReq, nil)
+	mockClient.On("CallWithRetry", putReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.Transaction) = commitTransaction(87, blnkgo.PryTransactionStatusApplied)
+	})
+
+	transaction, err := svc.CommitInflight("tx-1", 87)
+	assert.NoError(t, err)
+	assert.Equal(t, 87.0, transaction.Amount)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTransactionService_CommitInflight_OverCommit(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupInflightMocks(mockClient, inflightTransaction(100), []blnkgo.Transaction{
+		commitTransaction(60, blnkgo.PryTransactionStatusApplied),
+	})
+
+	transaction, err := svc.CommitInflight("tx-1", 40.01)
+	assert.Nil(t, transaction)
+	assert.True(t, errors.Is(err, blnkgo.ErrInflightOverCommit))
+	mockClient.AssertNotCalled(t, "NewRequest", "transactions/inflight/tx-1", http.MethodPut, mock.Anything)
+}
+
+func TestTransactionService_VoidInflight(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupInflightMocks(mockClient, inflightTransaction(100), []blnkgo.Transaction{
+		commitTransaction(60, blnkgo.PryTransactionStatusApplied),
+	})
+
+	body := blnkgo.UpdateStatus{Status: blnkgo.InflightStatusVoid}
+	putReq := &http.Request{Method: http.MethodPut}
+	mockClient.On("NewRequest", "transactions/inflight/tx-1", http.MethodPut, body).Return(putReq, nil)
+	mockClient.On("CallWithRetry", putReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.Transaction) = commitTransaction(40, blnkgo.PryTransactionStatusVoid)
+	})
+
+	transaction, err := svc.VoidInflight("tx-1")
+	assert.NoError(t, err)
+	assert.Equal(t, blnkgo.PryTransactionStatusVoid, transaction.Status)
+	mockClient.AssertExpectations(t)
+}
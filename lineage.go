@@ -0,0 +1,110 @@
+package blnkgo
+
+import "fmt"
+
+// maxLineageDepth guards against parent cycles when walking up to the root transaction
+const maxLineageDepth = 32
+
+// LineageRelation describes how a transaction relates to its parent.
+type LineageRelation string
+
+const (
+	LineageRoot   LineageRelation = "root"
+	LineageSplit  LineageRelation = "split"
+	LineageCommit LineageRelation = "commit"
+	LineageVoid   LineageRelation = "void"
+	LineageRefund LineageRelation = "refund"
+	LineageChild  LineageRelation = "child"
+)
+
+// TransactionLineage is a node in the family tree of a transaction.
+type TransactionLineage struct {
+	Transaction Transaction           `json:"transaction"`
+	Relation    LineageRelation       `json:"relation"`
+	Children    []*TransactionLineage `json:"children,omitempty"`
+}
+
+// Flatten returns every transaction in the tree, parents before their children
+func (l *TransactionLineage) Flatten() []Transaction {
+	transactions := []Transaction{l.Transaction}
+	for _, child := range l.Children {
+		transactions = append(transactions, child.Flatten()...)
+	}
+	return transactions
+}
+
+// Find returns the node for transactionID, or nil if it is not part of the tree
+func (l *TransactionLineage) Find(transactionID string) *TransactionLineage {
+	if l.Transaction.TransactionID == transactionID {
+		return l
+	}
+	for _, child := range l.Children {
+		if found := child.Find(transactionID); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// GetLineage returns the full family of a transaction: it walks up to the root transaction
+// and then down through every split leg, inflight commit, void and refund.
+func (s *TransactionService) GetLineage(transactionID string) (*TransactionLineage, error) {
+	if transactionID == "" {
+		return nil, fmt.Errorf("transactionID is required")
+	}
+
+	root, _, err := s.Get(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	for depth := 0; root.ParentTransactionID != ""; depth++ {
+		if depth >= maxLineageDepth {
+			return nil, fmt.Errorf("lineage of %s is deeper than %d levels", transactionID, maxLineageDepth)
+		}
+		root, _, err = s.Get(root.ParentTransactionID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	search := NewSearchService(s.client)
+	tree := &TransactionLineage{Transaction: *root, Relation: LineageRoot}
+	seen := map[string]bool{root.TransactionID: true}
+	queue := []*TransactionLineage{tree}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+
+		children, err := search.searchAllTransactions("parent_transaction:=" + node.Transaction.TransactionID)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			if seen[child.TransactionID] {
+				continue
+			}
+			seen[child.TransactionID] = true
+			childNode := &TransactionLineage{Transaction: child, Relation: lineageRelation(&node.Transaction, child)}
+			node.Children = append(node.Children, childNode)
+			queue = append(queue, childNode)
+		}
+	}
+
+	return tree, nil
+}
+
+// lineageRelation classifies child relative to parent
+func lineageRelation(parent *Transaction, child Transaction) LineageRelation {
+	switch {
+	case child.Status == PryTransactionStatusVoid:
+		return LineageVoid
+	case isRefundOf(child, parent):
+		return LineageRefund
+	case len(parent.Sources) > 0 || len(parent.Destinations) > 0:
+		return LineageSplit
+	case parent.Status == PryTransactionStatusInFlight:
+		return LineageCommit
+	default:
+		return LineageChild
+	}
+}
@@ -0,0 +1,90 @@
+package blnkgo_test
+
+import (
+	"net/http"
+	"testing"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// setupLineageMocks stubs Get for every transaction and a children search for every parent
+func setupLineageMocks(m *MockClient, transactions []blnkgo.Transaction) {
+	for _, tx := range transactions {
+		tx := tx
+		getReq := &http.Request{Method: http.MethodGet, RequestURI: tx.TransactionID}
+		m.On("NewRequest", "transactions/"+tx.TransactionID, http.MethodGet, nil).Return(getReq, nil).Maybe()
+		m.On("CallWithRetry", getReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+			*args.Get(1).(*blnkgo.Transaction) = tx
+		}).Maybe()
+
+		var children []blnkgo.Transaction
+		for _, c := range transactions {
+			if c.ParentTransactionID == tx.TransactionID {
+				children = append(children, c)
+			}
+		}
+		filter := "parent_transaction:=" + tx.TransactionID
+		searchReq := &http.Request{Method: http.MethodPost, RequestURI: filter}
+		m.On("NewRequest", "search/transactions", http.MethodPost, mock.MatchedBy(func(p blnkgo.SearchParams) bool {
+			return *p.FilterBy == filter
+		})).Return(searchReq, nil).Maybe()
+		m.On("CallWithRetry", searchReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+			resp := args.Get(1).(*blnkgo.TransactionSearchResponse)
+			resp.Found = len(children)
+			for _, c := range children {
+				resp.Hits = append(resp.Hits, blnkgo.TransactionSearchHit{Document: c})
+			}
+		}).Maybe()
+	}
+}
+
+func TestTransactionService_GetLineage(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+
+	split := blnkgo.Transaction{
+		TransactionID: "split",
+		ParentTransaction: blnkgo.ParentTransaction{
+			Amount:       100,
+			Source:       "@customer",
+			Destinations: []blnkgo.Source{{Identifier: "@a", Distribution: "50%"}, {Identifier: "@b", Distribution: "left"}},
+			Status:       blnkgo.PryTransactionStatusInFlight,
+		},
+	}
+	legA := blnkgo.Transaction{TransactionID: "leg-a", ParentTransactionID: "split", ParentTransaction: blnkgo.ParentTransaction{
+		Amount: 50, Source: "@customer", Destination: "@a", Status: blnkgo.PryTransactionStatusInFlight,
+	}}
+	commitA := blnkgo.Transaction{TransactionID: "commit-a", ParentTransactionID: "leg-a", ParentTransaction: blnkgo.ParentTransaction{
+		Amount: 50, Source: "@customer", Destination: "@a", Status: blnkgo.PryTransactionStatusApplied,
+	}}
+	voidB := blnkgo.Transaction{TransactionID: "void-b", ParentTransactionID: "split", ParentTransaction: blnkgo.ParentTransaction{
+		Amount: 50, Source: "@customer", Destination: "@b", Status: blnkgo.PryTransactionStatusVoid,
+	}}
+	refundA := blnkgo.Transaction{TransactionID: "refund-a", ParentTransactionID: "commit-a", ParentTransaction: blnkgo.ParentTransaction{
+		Amount: 20, Source: "@a", Destination: "@customer", Status: blnkgo.PryTransactionStatusApplied,
+	}}
+	setupLineageMocks(mockClient, []blnkgo.Transaction{split, legA, commitA, voidB, refundA})
+
+	// start from a leaf, the tree must still be rooted at the original transaction
+	lineage, err := svc.GetLineage("refund-a")
+	assert.NoError(t, err)
+	assert.Equal(t, "split", lineage.Transaction.TransactionID)
+	assert.Equal(t, blnkgo.LineageRoot, lineage.Relation)
+	assert.Len(t, lineage.Flatten(), 5)
+
+	assert.Equal(t, blnkgo.LineageSplit, lineage.Find("leg-a").Relation)
+	assert.Equal(t, blnkgo.LineageVoid, lineage.Find("void-b").Relation)
+	assert.Equal(t, blnkgo.LineageCommit, lineage.Find("commit-a").Relation)
+	assert.Equal(t, blnkgo.LineageRefund, lineage.Find("refund-a").Relation)
+	assert.Nil(t, lineage.Find("missing"))
+}
+
+func TestTransactionService_GetLineage_EmptyID(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+
+	lineage, err := svc.GetLineage("")
+	assert.Error(t, err)
+	assert.Nil(t, lineage)
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
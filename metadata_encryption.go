@@ -0,0 +1,309 @@
+package blnkgo
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// encryptedValuePrefix marks a meta data value encrypted by a MetadataEncryptor, the
+// full format is enc:v1:<key id>:<wrapped data key>:<ciphertext>
+const encryptedValuePrefix = "enc:v1:"
+
+// dataKeySize is the size of the AES-256 key generated for every encrypted value
+const dataKeySize = 32
+
+var (
+	// ErrUnknownEncryptionKey is returned when a value was encrypted under a key the encryptor does not hold
+	ErrUnknownEncryptionKey = errors.New("unknown metadata encryption key")
+	// ErrMalformedCiphertext is returned when an encrypted value can not be parsed or authenticated
+	ErrMalformedCiphertext = errors.New("malformed metadata ciphertext")
+)
+
+// MetadataEncryptor encrypts selected meta_data keys with envelope encryption: every value
+// gets a fresh AES-256-GCM data key, which is itself sealed with AES-GCM under the active
+// key-encryption-key. The id of that key is stored in the ciphertext, so values written
+// before a rotation stay readable as long as the old key is kept. Every value is bound to
+// the meta data key it is stored under, so it can not be moved to another field unnoticed.
+// Values are not bound to the resource holding them, since ids are assigned by the server
+// after the value is encrypted.
+type MetadataEncryptor struct {
+	mu       sync.RWMutex
+	keys     map[string]cipher.AEAD
+	activeID string
+	fields   map[string]bool
+}
+
+// NewMetadataEncryptor creates an encryptor that encrypts the given meta data keys under
+// the key-encryption-key keyID. Keys must be 16, 24 or 32 bytes long.
+func NewMetadataEncryptor(keyID string, key []byte, fields ...string) (*MetadataEncryptor, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one meta data field to encrypt is required")
+	}
+	e := &MetadataEncryptor{
+		keys:   make(map[string]cipher.AEAD),
+		fields: make(map[string]bool),
+	}
+	for _, field := range fields {
+		e.fields[field] = true
+	}
+	if err := e.Rotate(keyID, key); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// AddKey makes a retired key-encryption-key available for decryption only
+func (e *MetadataEncryptor) AddKey(keyID string, key []byte) error {
+	aead, err := newKeyAEAD(keyID, key)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.keys[keyID] = aead
+	return nil
+}
+
+// Rotate adds a key-encryption-key and encrypts every new value with it. Earlier keys
+// are kept for decryption.
+func (e *MetadataEncryptor) Rotate(keyID string, key []byte) error {
+	aead, err := newKeyAEAD(keyID, key)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.keys[keyID] = aead
+	e.activeID = keyID
+	return nil
+}
+
+// ActiveKeyID returns the id of the key used for new values
+func (e *MetadataEncryptor) ActiveKeyID() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.activeID
+}
+
+// Encrypt seals the JSON encoding of value, stored under the meta data key field, with the active key
+func (e *MetadataEncryptor) Encrypt(field string, value interface{}) (string, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	e.mu.RLock()
+	keyID, kek := e.activeID, e.keys[e.activeID]
+	e.mu.RUnlock()
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", err
+	}
+	dek, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	//the key id is bound to the wrapped data key so it can not be swapped
+	wrappedKey, err := seal(kek, dataKey, []byte(keyID))
+	if err != nil {
+		return "", err
+	}
+	//the field is bound to the value so it can not be moved to another key
+	ciphertext, err := seal(dek, plaintext, fieldAdditionalData(field))
+	if err != nil {
+		return "", err
+	}
+
+	return encryptedValuePrefix + keyID + ":" +
+		base64.RawStdEncoding.EncodeToString(wrappedKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(ciphertext), nil
+}
+
+// Decrypt opens a value Encrypt produced for field and returns the decoded JSON value
+func (e *MetadataEncryptor) Decrypt(field, value string) (interface{}, error) {
+	parts := strings.Split(strings.TrimPrefix(value, encryptedValuePrefix), ":")
+	if !IsEncryptedMetadataValue(value) || len(parts) != 3 {
+		return nil, ErrMalformedCiphertext
+	}
+	keyID := parts[0]
+
+	e.mu.RLock()
+	kek, ok := e.keys[keyID]
+	e.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEncryptionKey, keyID)
+	}
+
+	wrappedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformedCiphertext
+	}
+	ciphertext, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformedCiphertext
+	}
+
+	dataKey, err := open(kek, wrappedKey, []byte(keyID))
+	if err != nil {
+		return nil, err
+	}
+	dek, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, ErrMalformedCiphertext
+	}
+	plaintext, err := open(dek, ciphertext, fieldAdditionalData(field))
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := decodeJSONNumber(plaintext, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// IsEncryptedMetadataValue reports whether v looks like a value produced by a MetadataEncryptor
+func IsEncryptedMetadataValue(v interface{}) bool {
+	s, ok := v.(string)
+	return ok && strings.HasPrefix(s, encryptedValuePrefix)
+}
+
+// encryptBody returns body as JSON with the configured meta data keys encrypted
+func (e *MetadataEncryptor) encryptBody(body interface{}) (interface{}, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	var tree interface{}
+	if err := decodeJSONNumber(raw, &tree); err != nil {
+		return nil, err
+	}
+	if err := e.walkMetadata(tree, e.encryptMetadata); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// decryptBody returns the JSON document data with encrypted meta data values opened
+func (e *MetadataEncryptor) decryptBody(data []byte) ([]byte, error) {
+	var tree interface{}
+	if err := decodeJSONNumber(data, &tree); err != nil {
+		return nil, err
+	}
+	if err := e.walkMetadata(tree, e.decryptMetadata); err != nil {
+		return nil, err
+	}
+	return json.Marshal(tree)
+}
+
+func (e *MetadataEncryptor) encryptMetadata(metadata map[string]interface{}) error {
+	for key, value := range metadata {
+		//values that are already encrypted, such as ones read back and resent, are kept as is
+		if !e.fields[key] || value == nil || IsEncryptedMetadataValue(value) {
+			continue
+		}
+		encrypted, err := e.Encrypt(key, value)
+		if err != nil {
+			return fmt.Errorf("encrypting meta_data.%s: %w", key, err)
+		}
+		metadata[key] = encrypted
+	}
+	return nil
+}
+
+func (e *MetadataEncryptor) decryptMetadata(metadata map[string]interface{}) error {
+	for key, value := range metadata {
+		if !IsEncryptedMetadataValue(value) {
+			continue
+		}
+		decrypted, err := e.Decrypt(key, value.(string))
+		if err != nil {
+			return fmt.Errorf("decrypting meta_data.%s: %w", key, err)
+		}
+		metadata[key] = decrypted
+	}
+	return nil
+}
+
+// walkMetadata calls fn on every meta_data object found in a decoded JSON document
+func (e *MetadataEncryptor) walkMetadata(node interface{}, fn func(map[string]interface{}) error) error {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if metadata, ok := child.(map[string]interface{}); ok && key == "meta_data" {
+				if err := fn(metadata); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := e.walkMetadata(child, fn); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if err := e.walkMetadata(child, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodeJSONNumber decodes data keeping numbers as json.Number so large amounts survive
+func decodeJSONNumber(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// fieldAdditionalData is the additional data binding a value to its meta data key
+func fieldAdditionalData(field string) []byte {
+	return []byte("meta_data." + field)
+}
+
+func newKeyAEAD(keyID string, key []byte) (cipher.AEAD, error) {
+	if keyID == "" || strings.Contains(keyID, ":") {
+		return nil, fmt.Errorf("key id must be set and can not contain ':'")
+	}
+	return newAEAD(key)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext and prepends the random nonce
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformedCiphertext
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], additionalData)
+	if err != nil {
+		return nil, ErrMalformedCiphertext
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,150 @@
+package blnkgo_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	kek1 = bytes.Repeat([]byte{1}, 32)
+	kek2 = bytes.Repeat([]byte{2}, 32)
+)
+
+func TestMetadataEncryptor_RoundTrip(t *testing.T) {
+	enc, err := blnkgo.NewMetadataEncryptor("k1", kek1, "ssn")
+	require.NoError(t, err)
+
+	sealed, err := enc.Encrypt("ssn", map[string]interface{}{"number": "123-45-6789"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sealed, "enc:v1:k1:"))
+	assert.NotContains(t, sealed, "6789")
+
+	opened, err := enc.Decrypt("ssn", sealed)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"number": "123-45-6789"}, opened)
+
+	other, err := enc.Encrypt("ssn", map[string]interface{}{"number": "123-45-6789"})
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, other, "every value gets a fresh data key and nonce")
+}
+
+func TestMetadataEncryptor_Rotation(t *testing.T) {
+	enc, err := blnkgo.NewMetadataEncryptor("k1", kek1, "ssn")
+	require.NoError(t, err)
+	old, err := enc.Encrypt("ssn", "secret")
+	require.NoError(t, err)
+
+	require.NoError(t, enc.Rotate("k2", kek2))
+	assert.Equal(t, "k2", enc.ActiveKeyID())
+
+	fresh, err := enc.Encrypt("ssn", "secret")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(fresh, "enc:v1:k2:"))
+
+	for _, sealed := range []string{old, fresh} {
+		opened, err := enc.Decrypt("ssn", sealed)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", opened)
+	}
+
+	//a reader that only holds the new key can not open old values
+	reader, err := blnkgo.NewMetadataEncryptor("k2", kek2, "ssn")
+	require.NoError(t, err)
+	_, err = reader.Decrypt("ssn", old)
+	assert.ErrorIs(t, err, blnkgo.ErrUnknownEncryptionKey)
+
+	require.NoError(t, reader.AddKey("k1", kek1))
+	_, err = reader.Decrypt("ssn", old)
+	assert.NoError(t, err)
+	assert.Equal(t, "k2", reader.ActiveKeyID())
+}
+
+func TestMetadataEncryptor_Invalid(t *testing.T) {
+	_, err := blnkgo.NewMetadataEncryptor("k1", []byte("short"), "ssn")
+	assert.Error(t, err)
+	_, err = blnkgo.NewMetadataEncryptor("k:1", kek1, "ssn")
+	assert.Error(t, err)
+	_, err = blnkgo.NewMetadataEncryptor("k1", kek1)
+	assert.Error(t, err)
+
+	enc, err := blnkgo.NewMetadataEncryptor("k1", kek1, "ssn")
+	require.NoError(t, err)
+	sealed, err := enc.Encrypt("ssn", "secret")
+	require.NoError(t, err)
+
+	//the key id is authenticated, relabelling the value breaks it
+	require.NoError(t, enc.AddKey("k9", kek1))
+	_, err = enc.Decrypt("ssn", strings.Replace(sealed, "enc:v1:k1:", "enc:v1:k9:", 1))
+	assert.ErrorIs(t, err, blnkgo.ErrMalformedCiphertext)
+
+	_, err = enc.Decrypt("ssn", sealed[:len(sealed)-4])
+	assert.ErrorIs(t, err, blnkgo.ErrMalformedCiphertext)
+	_, err = enc.Decrypt("ssn", "plain")
+	assert.ErrorIs(t, err, blnkgo.ErrMalformedCiphertext)
+
+	//the value is bound to its meta data key, moving it to another key breaks it
+	_, err = enc.Decrypt("dob", sealed)
+	assert.ErrorIs(t, err, blnkgo.ErrMalformedCiphertext)
+}
+
+func TestClient_MetadataEncryption(t *testing.T) {
+	enc, err := blnkgo.NewMetadataEncryptor("k1", kek1, "ssn", "dob")
+	require.NoError(t, err)
+
+	var received map[string]interface{}
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(body, &received))
+
+		//the server stores the encrypted values and returns them as is
+		var ledger map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &ledger))
+		ledger["ledger_id"] = "ldg-1"
+		_ = json.NewEncoder(w).Encode(ledger)
+	}, blnkgo.WithMetadataEncryptor(enc))
+
+	ledger, _, err := client.Ledger.Create(blnkgo.CreateLedgerRequest{
+		Name:     "customers",
+		MetaData: map[string]interface{}{"ssn": "123-45-6789", "dob": "1990-01-01", "tier": "gold"},
+	})
+	require.NoError(t, err)
+
+	metadata := received["meta_data"].(map[string]interface{})
+	assert.True(t, blnkgo.IsEncryptedMetadataValue(metadata["ssn"]))
+	assert.True(t, blnkgo.IsEncryptedMetadataValue(metadata["dob"]))
+	assert.Equal(t, "gold", metadata["tier"])
+
+	assert.Equal(t, "ldg-1", ledger.LedgerID)
+	assert.Equal(t, map[string]interface{}{"ssn": "123-45-6789", "dob": "1990-01-01", "tier": "gold"}, ledger.MetaData)
+}
+
+func TestClient_MetadataEncryption_SwappedValuesRejected(t *testing.T) {
+	enc, err := blnkgo.NewMetadataEncryptor("k1", kek1, "ssn", "dob")
+	require.NoError(t, err)
+
+	client := newServerClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var ledger map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&ledger))
+
+		//someone with write access to the stored meta data swaps two encrypted values
+		metadata := ledger["meta_data"].(map[string]interface{})
+		metadata["ssn"], metadata["dob"] = metadata["dob"], metadata["ssn"]
+		ledger["ledger_id"] = "ldg-1"
+		_ = json.NewEncoder(w).Encode(ledger)
+	}, blnkgo.WithMetadataEncryptor(enc))
+
+	_, _, err = client.Ledger.Create(blnkgo.CreateLedgerRequest{
+		Name:     "customers",
+		MetaData: map[string]interface{}{"ssn": "123-45-6789", "dob": "1990-01-01"},
+	})
+	assert.ErrorIs(t, err, blnkgo.ErrMalformedCiphertext)
+}
@@ -0,0 +1,131 @@
+package blnkgo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ProvisionAction describes what ProvisionMonitors did for a single balance.
+type ProvisionAction string
+
+const (
+	ProvisionCreated   ProvisionAction = "created"
+	ProvisionUpdated   ProvisionAction = "updated"
+	ProvisionUnchanged ProvisionAction = "unchanged"
+	ProvisionFailed    ProvisionAction = "failed"
+)
+
+// defaultProvisionConcurrency is the number of balances provisioned at once when none is given
+const defaultProvisionConcurrency = 8
+
+// MonitorProvisionResult is the outcome of provisioning the monitor on one balance.
+type MonitorProvisionResult struct {
+	BalanceID string
+	MonitorID string
+	Action    ProvisionAction
+	Err       error
+}
+
+// ProvisionMonitors puts the monitor described by template on every balance matching
+// params. The template Description identifies the monitor: a balance that already has a
+// monitor with that description is updated when its condition or callback differ and left
+// alone otherwise, so re-running the same provisioning never creates duplicates.
+//
+// At most concurrency balances are handled at once. Failures on a single balance are
+// reported in its result, the returned error is only set when the search or the template
+// itself is invalid. Results follow the order of the search results.
+func (s *BalanceMonitorService) ProvisionMonitors(ctx context.Context, params SearchParams, template MonitorData, concurrency int) ([]MonitorProvisionResult, error) {
+	if template.Description == "" {
+		return nil, fmt.Errorf("template description is required to identify provisioned monitors")
+	}
+	//the balance id is filled in per balance, validate everything else up front
+	check := template
+	check.BalanceID = "provisioning"
+	if err := check.Validate(); err != nil {
+		return nil, err
+	}
+	if concurrency <= 0 {
+		concurrency = defaultProvisionConcurrency
+	}
+
+	balanceIDs, err := NewSearchService(s.client).searchAllBalanceIDs(params)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]MonitorProvisionResult, len(balanceIDs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, balanceID := range balanceIDs {
+		//check first, select picks at random when a slot is free and ctx is done
+		if ctx.Err() != nil {
+			results[i] = MonitorProvisionResult{BalanceID: balanceID, Action: ProvisionFailed, Err: ctx.Err()}
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			results[i] = MonitorProvisionResult{BalanceID: balanceID, Action: ProvisionFailed, Err: ctx.Err()}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, balanceID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = s.provisionMonitor(balanceID, template)
+		}(i, balanceID)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+func (s *BalanceMonitorService) provisionMonitor(balanceID string, template MonitorData) MonitorProvisionResult {
+	result := MonitorProvisionResult{BalanceID: balanceID, Action: ProvisionFailed}
+	data := template
+	data.BalanceID = balanceID
+
+	existing, _, err := s.ListByBalance(balanceID)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	for _, monitor := range existing {
+		if monitor.Description != template.Description {
+			continue
+		}
+		result.MonitorID = monitor.MonitorID
+		if sameMonitor(monitor.MonitorData, data) {
+			result.Action = ProvisionUnchanged
+			return result
+		}
+		if _, _, err := s.Update(monitor.MonitorID, data); err != nil {
+			result.Err = err
+			return result
+		}
+		result.Action = ProvisionUpdated
+		return result
+	}
+
+	created, _, err := s.Create(data)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.MonitorID = created.MonitorID
+	result.Action = ProvisionCreated
+	return result
+}
+
+// sameMonitor reports whether two monitors on the same balance would behave the same
+func sameMonitor(a, b MonitorData) bool {
+	return a.BalanceID == b.BalanceID &&
+		a.CallBackURL == b.CallBackURL &&
+		a.Condition == b.Condition &&
+		reflect.DeepEqual(a.ConditionGroup, b.ConditionGroup)
+}
@@ -0,0 +1,123 @@
+package blnkgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func lowBalanceTemplate() blnkgo.MonitorData {
+	return blnkgo.MonitorData{
+		Description: "low balance",
+		CallBackURL: "https://example.com/hooks",
+		Condition:   blnkgo.MonitorCondition{Field: "balance", Operator: blnkgo.OperatorLessThan, Value: 100, Precision: 100},
+	}
+}
+
+func stubBalanceSearch(m *MockClient, balanceIDs ...string) {
+	searchReq := &http.Request{Method: http.MethodPost, RequestURI: "search/balances"}
+	m.On("NewRequest", "search/balances", http.MethodPost, mock.Anything).Return(searchReq, nil)
+	m.On("CallWithRetry", searchReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		resp := *args.Get(1).(**blnkgo.SearchResponse)
+		resp.Found = len(balanceIDs)
+		for _, id := range balanceIDs {
+			resp.Hits = append(resp.Hits, blnkgo.SearchHit{Document: blnkgo.SearchDocument{BalanceID: id}})
+		}
+	})
+}
+
+func stubListByBalance(m *MockClient, balanceID string, monitors []blnkgo.MonitorDataResp, err error) {
+	req := &http.Request{Method: http.MethodGet, RequestURI: "balance-monitors/balances/" + balanceID}
+	m.On("NewRequest", "balance-monitors/balances/"+balanceID, http.MethodGet, nil).Return(req, nil)
+	if err != nil {
+		m.On("CallWithRetry", req, mock.Anything).Return(&http.Response{StatusCode: http.StatusInternalServerError}, err)
+		return
+	}
+	m.On("CallWithRetry", req, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*[]blnkgo.MonitorDataResp) = monitors
+	})
+}
+
+func TestBalanceMonitorService_ProvisionMonitors(t *testing.T) {
+	mockClient, svc := setupBalanceMonitorService()
+	template := lowBalanceTemplate()
+
+	stubBalanceSearch(mockClient, "bal-1", "bal-2", "bal-3", "bal-4")
+
+	existing := template
+	existing.BalanceID = "bal-2"
+	stubListByBalance(mockClient, "bal-1", nil, nil)
+	stubListByBalance(mockClient, "bal-2", []blnkgo.MonitorDataResp{{MonitorID: "mon-2", MonitorData: existing}}, nil)
+
+	stale := template
+	stale.BalanceID = "bal-3"
+	stale.Condition.Value = 50
+	stubListByBalance(mockClient, "bal-3", []blnkgo.MonitorDataResp{
+		{MonitorID: "mon-other", MonitorData: blnkgo.MonitorData{BalanceID: "bal-3", Description: "other"}},
+		{MonitorID: "mon-3", MonitorData: stale},
+	}, nil)
+	stubListByBalance(mockClient, "bal-4", nil, errors.New("server error"))
+
+	created := template
+	created.BalanceID = "bal-1"
+	createReq := &http.Request{Method: http.MethodPost, RequestURI: "balance-monitors"}
+	mockClient.On("NewRequest", "balance-monitors", http.MethodPost, created).Return(createReq, nil).Once()
+	mockClient.On("CallWithRetry", createReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusCreated}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.MonitorDataResp) = blnkgo.MonitorDataResp{MonitorID: "mon-1", MonitorData: created}
+	})
+
+	updated := template
+	updated.BalanceID = "bal-3"
+	updateReq := &http.Request{Method: http.MethodPut, RequestURI: "balance-monitors/mon-3"}
+	mockClient.On("NewRequest", "balance-monitors/mon-3", http.MethodPut, updated).Return(updateReq, nil).Once()
+	mockClient.On("CallWithRetry", updateReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil)
+
+	results, err := svc.ProvisionMonitors(context.Background(), blnkgo.SearchParams{}, template, 2)
+
+	assert.NoError(t, err)
+	assert.Len(t, results, 4)
+	assert.Equal(t, blnkgo.MonitorProvisionResult{BalanceID: "bal-1", MonitorID: "mon-1", Action: blnkgo.ProvisionCreated}, results[0])
+	assert.Equal(t, blnkgo.MonitorProvisionResult{BalanceID: "bal-2", MonitorID: "mon-2", Action: blnkgo.ProvisionUnchanged}, results[1])
+	assert.Equal(t, blnkgo.MonitorProvisionResult{BalanceID: "bal-3", MonitorID: "mon-3", Action: blnkgo.ProvisionUpdated}, results[2])
+	assert.Equal(t, blnkgo.ProvisionFailed, results[3].Action)
+	assert.ErrorContains(t, results[3].Err, "server error")
+	mockClient.AssertExpectations(t)
+}
+
+func TestBalanceMonitorService_ProvisionMonitors_InvalidTemplate(t *testing.T) {
+	mockClient, svc := setupBalanceMonitorService()
+
+	template := lowBalanceTemplate()
+	template.Description = ""
+	_, err := svc.ProvisionMonitors(context.Background(), blnkgo.SearchParams{}, template, 0)
+	assert.ErrorContains(t, err, "description is required")
+
+	template = lowBalanceTemplate()
+	template.Condition.Field = "available"
+	_, err = svc.ProvisionMonitors(context.Background(), blnkgo.SearchParams{}, template, 0)
+	var verr *blnkgo.ValidationError
+	assert.ErrorAs(t, err, &verr)
+	assert.True(t, verr.HasField("condition.field"))
+	mockClient.AssertNotCalled(t, "NewRequest", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBalanceMonitorService_ProvisionMonitors_Cancelled(t *testing.T) {
+	mockClient, svc := setupBalanceMonitorService()
+	stubBalanceSearch(mockClient, "bal-1", "bal-2")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results, err := svc.ProvisionMonitors(ctx, blnkgo.SearchParams{}, lowBalanceTemplate(), 1)
+
+	assert.NoError(t, err)
+	for _, result := range results {
+		assert.Equal(t, blnkgo.ProvisionFailed, result.Action)
+		assert.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
@@ -0,0 +1,56 @@
+package blnkgo
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimit caps the rate of outgoing requests.
+type RateLimit struct {
+	RequestsPerSecond float64 `json:"requests_per_second" yaml:"requests_per_second"`
+	Burst             int     `json:"burst" yaml:"burst"`
+}
+
+// rateLimiter is a token bucket shared by every request made through a client
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(limit RateLimit) *rateLimiter {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: limit.RequestsPerSecond, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait blocks until a request may be sent
+func (l *rateLimiter) wait() {
+	if l.rate <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	//take the token now, going negative reserves it for the caller
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
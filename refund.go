@@ -0,0 +1,178 @@
+package blnkgo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+)
+
+// ErrRefundExceedsOriginal is returned when a refund would return more than the original transaction moved
+var ErrRefundExceedsOriginal = errors.New("refund amount exceeds the refundable amount")
+
+// RefundReason is a machine readable reason attached to a refund.
+type RefundReason string
+
+const (
+	RefundReasonDuplicate           RefundReason = "duplicate"
+	RefundReasonFraudulent          RefundReason = "fraudulent"
+	RefundReasonRequestedByCustomer RefundReason = "requested_by_customer"
+	RefundReasonProductNotReceived  RefundReason = "product_not_received"
+	RefundReasonOther               RefundReason = "other"
+)
+
+// RefundRequest describes a refund of part or all of a transaction.
+// A zero Amount refunds whatever has not been refunded yet.
+type RefundRequest struct {
+	Amount   float64                `json:"amount,omitempty"`
+	Reason   RefundReason           `json:"reason,omitempty"`
+	MetaData map[string]interface{} `json:"meta_data,omitempty"`
+}
+
+// RefundSummary describes how much of a transaction has been refunded.
+type RefundSummary struct {
+	TransactionID     string        `json:"transaction_id"`
+	Precision         int64         `json:"precision"`
+	Original          float64       `json:"original"`
+	Refunded          float64       `json:"refunded"`
+	Refundable        float64       `json:"refundable"`
+	PreciseRefundable int64         `json:"precise_refundable"`
+	Refunds           []Transaction `json:"refunds"`
+}
+
+// ListRefunds returns the refunds made against a transaction. Refunds are the child
+// transactions that move funds back from the original destination to the original source.
+func (s *TransactionService) ListRefunds(transactionID string) ([]Transaction, error) {
+	summary, err := s.GetRefundSummary(transactionID)
+	if err != nil {
+		return nil, err
+	}
+	return summary.Refunds, nil
+}
+
+// GetRefundSummary returns the original amount, the amount already refunded and what is left to refund
+func (s *TransactionService) GetRefundSummary(transactionID string) (*RefundSummary, error) {
+	if transactionID == "" {
+		return nil, fmt.Errorf("transactionID is required")
+	}
+
+	original, _, err := s.Get(transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := NewSearchService(s.client).searchAllTransactions("parent_transaction:=" + transactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	precision := original.Precision
+	if precision <= 0 {
+		precision = 1
+	}
+	summary := &RefundSummary{
+		TransactionID: transactionID,
+		Precision:     precision,
+		Original:      original.Amount,
+		Refunds:       []Transaction{},
+	}
+
+	var refunded int64
+	for _, child := range children {
+		if !isRefundOf(child, original) {
+			continue
+		}
+		summary.Refunds = append(summary.Refunds, child)
+		if child.Status != PryTransactionStatusRejected {
+			refunded += toPreciseAmount(child.ParentTransaction)
+		}
+	}
+
+	refundable := toPreciseAmount(original.ParentTransaction) - refunded
+	if refundable < 0 {
+		refundable = 0
+	}
+	summary.Refunded = float64(refunded) / float64(precision)
+	summary.Refundable = float64(refundable) / float64(precision)
+	summary.PreciseRefundable = refundable
+
+	return summary, nil
+}
+
+// RefundPartial refunds part of a transaction with an optional reason and metadata.
+// Several partial refunds can be made as long as their total does not exceed the original amount.
+func (s *TransactionService) RefundPartial(transactionID string, body RefundRequest) (*Transaction, *http.Response, error) {
+	if body.Amount < 0 {
+		return nil, nil, fmt.Errorf("amount can not be negative")
+	}
+
+	summary, err := s.GetRefundSummary(transactionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if summary.PreciseRefundable == 0 {
+		return nil, nil, fmt.Errorf("%w: %s has been fully refunded", ErrRefundExceedsOriginal, transactionID)
+	}
+	if int64(math.Round(body.Amount*float64(summary.Precision))) > summary.PreciseRefundable {
+		return nil, nil, fmt.Errorf("%w: requested %v, refundable %v", ErrRefundExceedsOriginal, body.Amount, summary.Refundable)
+	}
+	//the server refunds the full original amount when none is sent, so send the remainder
+	if body.Amount == 0 {
+		body.Amount = summary.Refundable
+	}
+
+	u := fmt.Sprintf("refund-transaction/%s", transactionID)
+	req, err := s.client.NewRequest(u, http.MethodPost, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transaction := new(Transaction)
+	resp, err := s.client.CallWithRetry(req, transaction)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return transaction, resp, nil
+}
+
+// isRefundOf reports whether child reverses the direction of original: every balance it
+// debits was credited by original and every balance it credits was debited by original.
+// Both sides are read from Source/Destination and Sources/Destinations, so multi-source
+// and multi-destination payments are matched too. Split children of original move funds
+// in the same direction and are not counted.
+func isRefundOf(child Transaction, original *Transaction) bool {
+	childSources := transactionParties(child.Source, child.Sources)
+	childDestinations := transactionParties(child.Destination, child.Destinations)
+	if len(childSources) == 0 || len(childDestinations) == 0 {
+		return false
+	}
+	originalSources := transactionParties(original.Source, original.Sources)
+	originalDestinations := transactionParties(original.Destination, original.Destinations)
+
+	for id := range childSources {
+		if !originalDestinations[id] {
+			return false
+		}
+	}
+	for id := range childDestinations {
+		if !originalSources[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// transactionParties returns the balance identifiers on one side of a transaction
+func transactionParties(single string, multiple []Source) map[string]bool {
+	parties := make(map[string]bool)
+	if single != "" {
+		parties[single] = true
+	}
+	for _, s := range multiple {
+		if s.Identifier != "" {
+			parties[s.Identifier] = true
+		}
+	}
+	return parties
+}
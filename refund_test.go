@@ -0,0 +1,154 @@
+package blnkgo_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func appliedPayment() blnkgo.Transaction {
+	return blnkgo.Transaction{
+		TransactionID: "tx-1",
+		ParentTransaction: blnkgo.ParentTransaction{
+			Amount:      100,
+			Precision:   100,
+			Currency:    "USD",
+			Source:      "@customer",
+			Destination: "@merchant",
+			Status:      blnkgo.PryTransactionStatusApplied,
+		},
+	}
+}
+
+func refundTransaction(amount float64, status blnkgo.PryTransactionStatus) blnkgo.Transaction {
+	return blnkgo.Transaction{
+		ParentTransaction: blnkgo.ParentTransaction{
+			Amount:      amount,
+			Precision:   100,
+			Source:      "@merchant",
+			Destination: "@customer",
+			Status:      status,
+		},
+	}
+}
+
+func TestTransactionService_GetRefundSummary(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupInflightMocks(mockClient, appliedPayment(), []blnkgo.Transaction{
+		refundTransaction(25, blnkgo.PryTransactionStatusApplied),
+		refundTransaction(10, blnkgo.PryTransactionStatusRejected),
+		// same direction as the original, not a refund
+		commitTransaction(5, blnkgo.PryTransactionStatusApplied),
+	})
+
+	summary, err := svc.GetRefundSummary("tx-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 25.0, summary.Refunded)
+	assert.Equal(t, 75.0, summary.Refundable)
+	assert.Len(t, summary.Refunds, 2)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTransactionService_RefundPartial(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupInflightMocks(mockClient, appliedPayment(), []blnkgo.Transaction{
+		refundTransaction(25, blnkgo.PryTransactionStatusApplied),
+	})
+
+	body := blnkgo.RefundRequest{
+		Amount:   75,
+		Reason:   blnkgo.RefundReasonRequestedByCustomer,
+		MetaData: map[string]interface{}{"ticket": "T-1"},
+	}
+	refundReq := &http.Request{Method: http.MethodPost, RequestURI: "refund-transaction/tx-1"}
+	mockClient.On("NewRequest", "refund-transaction/tx-1", http.MethodPost, body).Return(refundReq, nil)
+	mockClient.On("CallWithRetry", refundReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusCreated}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.Transaction) = refundTransaction(75, blnkgo.PryTransactionStatusQueued)
+	})
+
+	transaction, resp, err := svc.RefundPartial("tx-1", body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, 75.0, transaction.Amount)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTransactionService_RefundPartial_ZeroAmountRefundsRemainder(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupInflightMocks(mockClient, appliedPayment(), []blnkgo.Transaction{
+		refundTransaction(25, blnkgo.PryTransactionStatusApplied),
+	})
+
+	refundReq := &http.Request{Method: http.MethodPost, RequestURI: "refund-transaction/tx-1"}
+	mockClient.On("NewRequest", "refund-transaction/tx-1", http.MethodPost, blnkgo.RefundRequest{Amount: 75}).Return(refundReq, nil)
+	mockClient.On("CallWithRetry", refundReq, mock.Anything).Return(&http.Response{StatusCode: http.StatusCreated}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.Transaction) = refundTransaction(75, blnkgo.PryTransactionStatusQueued)
+	})
+
+	transaction, _, err := svc.RefundPartial("tx-1", blnkgo.RefundRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 75.0, transaction.Amount)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTransactionService_RefundPartial_ExceedsOriginal(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupInflightMocks(mockClient, appliedPayment(), []blnkgo.Transaction{
+		refundTransaction(60, blnkgo.PryTransactionStatusApplied),
+	})
+
+	transaction, resp, err := svc.RefundPartial("tx-1", blnkgo.RefundRequest{Amount: 40.01})
+	assert.True(t, errors.Is(err, blnkgo.ErrRefundExceedsOriginal))
+	assert.Nil(t, transaction)
+	assert.Nil(t, resp)
+	mockClient.AssertNotCalled(t, "NewRequest", "refund-transaction/tx-1", http.MethodPost, mock.Anything)
+}
+
+func TestTransactionService_RefundPartial_NegativeAmount(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+
+	transaction, resp, err := svc.RefundPartial("tx-1", blnkgo.RefundRequest{Amount: -1})
+	assert.Error(t, err)
+	assert.Nil(t, transaction)
+	assert.Nil(t, resp)
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
+
+func TestTransactionService_RefundPartial_MultiSourcePayment(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	payment := appliedPayment()
+	payment.Source = ""
+	payment.Sources = []blnkgo.Source{
+		{Identifier: "@card", Distribution: "60%"},
+		{Identifier: "@wallet", Distribution: "left"},
+	}
+
+	refund := refundTransaction(30, blnkgo.PryTransactionStatusApplied)
+	refund.Destination = ""
+	refund.Destinations = []blnkgo.Source{
+		{Identifier: "@card", Distribution: "60%"},
+		{Identifier: "@wallet", Distribution: "left"},
+	}
+	partialRefund := refundTransaction(10, blnkgo.PryTransactionStatusApplied)
+	partialRefund.Destination = "@wallet"
+	// a split of the original payment moves funds the same way and is not a refund
+	split := commitTransaction(60, blnkgo.PryTransactionStatusApplied)
+	split.Source = "@card"
+	split.Destination = "@merchant"
+
+	setupInflightMocks(mockClient, payment, []blnkgo.Transaction{refund, partialRefund, split})
+
+	summary, err := svc.GetRefundSummary("tx-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 40.0, summary.Refunded)
+	assert.Equal(t, 60.0, summary.Refundable)
+	assert.Len(t, summary.Refunds, 2)
+
+	_, _, err = svc.RefundPartial("tx-1", blnkgo.RefundRequest{Amount: 60.01})
+	assert.ErrorIs(t, err, blnkgo.ErrRefundExceedsOriginal)
+	mockClient.AssertNotCalled(t, "NewRequest", "refund-transaction/tx-1", http.MethodPost, mock.Anything)
+}
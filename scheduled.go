@@ -0,0 +1,123 @@
+package blnkgo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ScheduledTransactionFilter narrows the scheduled transactions returned by ListScheduled.
+// Zero values are ignored, From defaults to now so only upcoming transactions are listed.
+type ScheduledTransactionFilter struct {
+	BalanceID string
+	From      time.Time
+	To        time.Time
+	Page      int
+	PerPage   int
+}
+
+// RescheduleRequest moves a scheduled transaction to a new execution time.
+type RescheduleRequest struct {
+	ScheduledFor time.Time `json:"scheduled_for"`
+}
+
+// filterBy builds the search filter for f
+func (f ScheduledTransactionFilter) filterBy(now time.Time) string {
+	from := f.From
+	if from.IsZero() {
+		from = now
+	}
+	filters := []string{
+		fmt.Sprintf("status:=%s", PryTransactionStatusQueued),
+		fmt.Sprintf("scheduled_for:>=%d", from.Unix()),
+	}
+	if !f.To.IsZero() {
+		filters = append(filters, fmt.Sprintf("scheduled_for:<=%d", f.To.Unix()))
+	}
+	if f.BalanceID != "" {
+		filters = append(filters, fmt.Sprintf("(source:=%s || destination:=%s)", f.BalanceID, f.BalanceID))
+	}
+	return strings.Join(filters, " && ")
+}
+
+// ListScheduled lists transactions that are scheduled to run in the future, ordered by execution time.
+func (s *TransactionService) ListScheduled(filter ScheduledTransactionFilter) ([]Transaction, *http.Response, error) {
+	if !filter.To.IsZero() && !filter.From.IsZero() && filter.To.Before(filter.From) {
+		return nil, nil, fmt.Errorf("to must not be before from")
+	}
+
+	filterBy := filter.filterBy(time.Now())
+	sortBy := "scheduled_for:asc"
+	params := SearchParams{
+		Q:        "*",
+		FilterBy: &filterBy,
+		SortBy:   &sortBy,
+	}
+	if filter.Page > 0 {
+		params.Page = &filter.Page
+	}
+	if filter.PerPage > 0 {
+		params.PerPage = &filter.PerPage
+	}
+
+	result, resp, err := NewSearchService(s.client).SearchTransactions(params)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	transactions := make([]Transaction, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		transactions = append(transactions, hit.Document)
+	}
+	return transactions, resp, nil
+}
+
+// CancelScheduled cancels a scheduled transaction before it runs
+func (s *TransactionService) CancelScheduled(transactionID string) (*Transaction, *http.Response, error) {
+	if err := requireFeature(s.client, FeatureScheduledTransactions); err != nil {
+		return nil, nil, err
+	}
+	if transactionID == "" {
+		return nil, nil, fmt.Errorf("transactionID is required")
+	}
+	u := fmt.Sprintf("transactions/scheduled/%s", transactionID)
+	req, err := s.client.NewRequest(u, http.MethodDelete, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transaction := new(Transaction)
+	resp, err := s.client.CallWithRetry(req, transaction)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return transaction, resp, nil
+}
+
+// Reschedule changes when a scheduled transaction runs, the new time must be in the future
+func (s *TransactionService) Reschedule(transactionID string, scheduledFor time.Time) (*Transaction, *http.Response, error) {
+	if err := requireFeature(s.client, FeatureScheduledTransactions); err != nil {
+		return nil, nil, err
+	}
+	if transactionID == "" {
+		return nil, nil, fmt.Errorf("transactionID is required")
+	}
+	if !scheduledFor.After(time.Now()) {
+		return nil, nil, fmt.Errorf("scheduledFor must be in the future")
+	}
+	u := fmt.Sprintf("transactions/scheduled/%s", transactionID)
+	req, err := s.client.NewRequest(u, http.MethodPut, RescheduleRequest{ScheduledFor: scheduledFor})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	transaction := new(Transaction)
+	resp, err := s.client.CallWithRetry(req, transaction)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return transaction, resp, nil
+}
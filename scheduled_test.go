@@ -0,0 +1,120 @@
+package blnkgo_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTransactionService_ListScheduled(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+
+	from := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	runAt := from.Add(time.Hour)
+
+	mockClient.On("NewRequest", "search/transactions", http.MethodPost, mock.MatchedBy(func(p blnkgo.SearchParams) bool {
+		return *p.FilterBy == "status:=QUEUED && scheduled_for:>=1893456000 && scheduled_for:<=1893542400 && (source:=bln-1 || destination:=bln-1)" &&
+			*p.SortBy == "scheduled_for:asc"
+	})).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		//the index holds dates as the Unix seconds the filter ranges over
+		payload := `{"found":1,"hits":[{"document":{"transaction_id":"tx-1","status":"QUEUED","scheduled_for":1893459600,"created_at":1893412800}}]}`
+		assert.NoError(t, json.Unmarshal([]byte(payload), args.Get(1)))
+	})
+
+	transactions, resp, err := svc.ListScheduled(blnkgo.ScheduledTransactionFilter{BalanceID: "bln-1", From: from, To: to})
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.Len(t, transactions, 1)
+	assert.True(t, runAt.Equal(*transactions[0].ScheduledFor))
+	assert.True(t, from.Add(-12*time.Hour).Equal(transactions[0].CreatedAt))
+	mockClient.AssertExpectations(t)
+}
+
+func TestTransactionSearchHit_DecodesDates(t *testing.T) {
+	at := time.Date(2030, time.January, 1, 1, 0, 0, 0, time.UTC)
+	for _, payload := range []string{
+		`{"document":{"transaction_id":"tx-1","scheduled_for":1893459600,"created_at":1893459600}}`,
+		`{"document":{"transaction_id":"tx-1","scheduled_for":"2030-01-01T01:00:00Z","created_at":"2030-01-01T01:00:00Z"}}`,
+	} {
+		var hit blnkgo.TransactionSearchHit
+		assert.NoError(t, json.Unmarshal([]byte(payload), &hit))
+		assert.Equal(t, "tx-1", hit.Document.TransactionID)
+		assert.True(t, at.Equal(*hit.Document.ScheduledFor), payload)
+		assert.True(t, at.Equal(hit.Document.CreatedAt), payload)
+	}
+
+	var hit blnkgo.TransactionSearchHit
+	assert.NoError(t, json.Unmarshal([]byte(`{"document":{"transaction_id":"tx-1","scheduled_for":null}}`), &hit))
+	assert.Nil(t, hit.Document.ScheduledFor)
+}
+
+func TestTransactionService_ListScheduled_InvalidRange(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	now := time.Now()
+
+	transactions, resp, err := svc.ListScheduled(blnkgo.ScheduledTransactionFilter{From: now, To: now.Add(-time.Hour)})
+	assert.Error(t, err)
+	assert.Nil(t, transactions)
+	assert.Nil(t, resp)
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
+
+func TestTransactionService_CancelScheduled(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+
+	mockClient.On("NewRequest", "transactions/scheduled/tx-1", http.MethodDelete, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.Transaction) = blnkgo.Transaction{TransactionID: "tx-1"}
+	})
+
+	transaction, resp, err := svc.CancelScheduled("tx-1")
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	assert.Equal(t, "tx-1", transaction.TransactionID)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTransactionService_CancelScheduled_ServerError(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+
+	mockClient.On("NewRequest", "transactions/scheduled/tx-1", http.MethodDelete, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusInternalServerError}, errors.New("server error"))
+
+	transaction, resp, err := svc.CancelScheduled("tx-1")
+	assert.Error(t, err)
+	assert.Nil(t, transaction)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+}
+
+func TestTransactionService_Reschedule(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	runAt := time.Now().Add(48 * time.Hour)
+
+	mockClient.On("NewRequest", "transactions/scheduled/tx-1", http.MethodPut, blnkgo.RescheduleRequest{ScheduledFor: runAt}).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		*args.Get(1).(*blnkgo.Transaction) = blnkgo.Transaction{TransactionID: "tx-1", ScheduledFor: &runAt}
+	})
+
+	transaction, _, err := svc.Reschedule("tx-1", runAt)
+	assert.NoError(t, err)
+	assert.Equal(t, runAt, *transaction.ScheduledFor)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTransactionService_Reschedule_PastTime(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+
+	transaction, resp, err := svc.Reschedule("tx-1", time.Now().Add(-time.Minute))
+	assert.Error(t, err)
+	assert.Nil(t, transaction)
+	assert.Nil(t, resp)
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
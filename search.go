@@ -1,6 +1,7 @@
 package blnkgo
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -8,6 +9,9 @@ import (
 
 type SearchService service
 
+// searchPageSize is the page size used when a helper pages through every search result
+const searchPageSize = 250
+
 type SearchParams struct {
 	Q        string  `json:"q"`
 	QueryBy  *string `json:"query_by,omitempty"`
@@ -57,3 +61,173 @@ func (s *SearchService) SearchDocument(body SearchParams, resource ResourceType)
 
 	return searchResponse, resp, nil
 }
+
+// TransactionSearchResponse is the search response for the transactions resource.
+type TransactionSearchResponse struct {
+	Found         int                    `json:"found"`
+	OutOf         int                    `json:"out_of"`
+	Page          int                    `json:"page"`
+	RequestParams SearchParams           `json:"request_params"`
+	SearchTimeMs  int                    `json:"search_time_ms"`
+	Hits          []TransactionSearchHit `json:"hits"`
+}
+
+type TransactionSearchHit struct {
+	Document Transaction `json:"document"`
+}
+
+// transactionSearchDates are the transaction fields the search index stores as Unix seconds
+var transactionSearchDates = []string{"created_at", "scheduled_for"}
+
+func (h *TransactionSearchHit) UnmarshalJSON(data []byte) error {
+	document, err := searchHitDocument(data, transactionSearchDates...)
+	if err != nil || document == nil {
+		return err
+	}
+	return json.Unmarshal(document, &h.Document)
+}
+
+// searchHitDocument returns the document of a search hit with the given date fields turned
+// from the Unix seconds the index range filters on into RFC 3339 strings, so documents decode
+// like the resources returned by the rest of the API. Dates already in RFC 3339 are kept.
+func searchHitDocument(data []byte, dateFields ...string) ([]byte, error) {
+	var hit struct {
+		Document map[string]json.RawMessage `json:"document"`
+	}
+	if err := json.Unmarshal(data, &hit); err != nil {
+		return nil, err
+	}
+	if hit.Document == nil {
+		return nil, nil
+	}
+	for _, field := range dateFields {
+		raw := hit.Document[field]
+		if len(raw) == 0 || (raw[0] != '-' && (raw[0] < '0' || raw[0] > '9')) {
+			continue
+		}
+		var seconds int64
+		if err := json.Unmarshal(raw, &seconds); err != nil {
+			return nil, fmt.Errorf("invalid %s in search document: %w", field, err)
+		}
+		formatted, err := json.Marshal(time.Unix(seconds, 0).UTC().Format(time.RFC3339))
+		if err != nil {
+			return nil, err
+		}
+		hit.Document[field] = formatted
+	}
+	return json.Marshal(hit.Document)
+}
+
+func (s *SearchService) SearchTransactions(body SearchParams) (*TransactionSearchResponse, *http.Response, error) {
+	u := fmt.Sprintf("search/%s", Transactions)
+	req, err := s.client.NewRequest(u, http.MethodPost, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	searchResponse := new(TransactionSearchResponse)
+	resp, err := s.client.CallWithRetry(req, searchResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return searchResponse, resp, nil
+}
+
+// IdentitySearchResponse is the search response for the identities resource.
+type IdentitySearchResponse struct {
+	Found         int                 `json:"found"`
+	OutOf         int                 `json:"out_of"`
+	Page          int                 `json:"page"`
+	RequestParams SearchParams        `json:"request_params"`
+	SearchTimeMs  int                 `json:"search_time_ms"`
+	Hits          []IdentitySearchHit `json:"hits"`
+}
+
+type IdentitySearchHit struct {
+	Document IdentityResponse `json:"document"`
+}
+
+func (h *IdentitySearchHit) UnmarshalJSON(data []byte) error {
+	document, err := searchHitDocument(data, "created_at")
+	if err != nil || document == nil {
+		return err
+	}
+	return json.Unmarshal(document, &h.Document)
+}
+
+func (s *SearchService) SearchIdentities(body SearchParams) (*IdentitySearchResponse, *http.Response, error) {
+	u := fmt.Sprintf("search/%s", Identities)
+	req, err := s.client.NewRequest(u, http.MethodPost, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	searchResponse := new(IdentitySearchResponse)
+	resp, err := s.client.CallWithRetry(req, searchResponse)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return searchResponse, resp, nil
+}
+
+// searchAllTransactions pages through every transaction matching filterBy
+func (s *SearchService) searchAllTransactions(filterBy string) ([]Transaction, error) {
+	perPage := searchPageSize
+	var transactions []Transaction
+	for page := 1; ; page++ {
+		p := page
+		result, _, err := s.SearchTransactions(SearchParams{
+			Q:        "*",
+			FilterBy: &filterBy,
+			Page:     &p,
+			PerPage:  &perPage,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, hit := range result.Hits {
+			transactions = append(transactions, hit.Document)
+		}
+		if len(result.Hits) < perPage || len(transactions) >= result.Found {
+			return transactions, nil
+		}
+	}
+}
+
+// searchAllBalanceIDs pages through every balance matching params, starting at the first page
+func (s *SearchService) searchAllBalanceIDs(params SearchParams) ([]string, error) {
+	perPage := searchPageSize
+	if params.PerPage != nil && *params.PerPage > 0 {
+		perPage = *params.PerPage
+	}
+	if params.Q == "" {
+		params.Q = "*"
+	}
+	params.PerPage = &perPage
+
+	var ids []string
+	seen := make(map[string]bool)
+	for page := 1; ; page++ {
+		p := page
+		params.Page = &p
+		result, _, err := s.SearchDocument(params, Balances)
+		if err != nil {
+			return nil, err
+		}
+		for _, hit := range result.Hits {
+			if id := hit.Document.BalanceID; id != "" && !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		if len(result.Hits) < perPage || page*perPage >= result.Found {
+			return ids, nil
+		}
+	}
+}
+
+func NewSearchService(client ClientInterface) *SearchService {
+	return &SearchService{client: client}
+}
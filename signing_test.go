@@ -0,0 +1,74 @@
+package blnkgo_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+)
+
+var signingSecret = []byte("signing-secret")
+
+func lookupSigningSecret(keyID string) ([]byte, error) {
+	if keyID != "key-1" {
+		return nil, errors.New("unknown key")
+	}
+	return signingSecret, nil
+}
+
+func TestClient_NewRequest_SignsMutatingRequests(t *testing.T) {
+	client := newTestClient(t, nil, blnkgo.WithRequestSigner(blnkgo.NewRequestSigner("key-1", signingSecret)))
+
+	req, err := client.NewRequest("transactions", http.MethodPost, map[string]interface{}{"amount": 10})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, req.Header.Get(blnkgo.SignatureHeader))
+	assert.Equal(t, "key-1", req.Header.Get(blnkgo.SignatureKeyIDHeader))
+	assert.NoError(t, blnkgo.VerifyRequestSignature(req, lookupSigningSecret, time.Minute))
+
+	//the verifier restores the body so the request can be forwarded
+	body, err := io.ReadAll(req.Body)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"amount":10}`, string(body))
+}
+
+func TestClient_NewRequest_DoesNotSignGet(t *testing.T) {
+	client := newTestClient(t, nil, blnkgo.WithRequestSigner(blnkgo.NewRequestSigner("key-1", signingSecret)))
+
+	req, err := client.NewRequest("ledgers", http.MethodGet, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, req.Header.Get(blnkgo.SignatureHeader))
+	assert.ErrorIs(t, blnkgo.VerifyRequestSignature(req, lookupSigningSecret, time.Minute), blnkgo.ErrMissingRequestSignature)
+}
+
+func TestClient_NewFileUploadRequest_IsSigned(t *testing.T) {
+	client := newTestClient(t, nil, blnkgo.WithRequestSigner(blnkgo.NewRequestSigner("key-1", signingSecret)))
+
+	req, err := client.NewFileUploadRequest("reconciliation/upload", "file", strings.NewReader("a,b\n"), "f.csv", map[string]string{"source": "bank"})
+	assert.NoError(t, err)
+	assert.NoError(t, blnkgo.VerifyRequestSignature(req, lookupSigningSecret, time.Minute))
+}
+
+func TestVerifyRequestSignature_TamperedBody(t *testing.T) {
+	client := newTestClient(t, nil, blnkgo.WithRequestSigner(blnkgo.NewRequestSigner("key-1", signingSecret)))
+
+	req, err := client.NewRequest("transactions", http.MethodPut, map[string]interface{}{"amount": 10})
+	assert.NoError(t, err)
+	req.Body = io.NopCloser(strings.NewReader(`{"amount":1000}`))
+
+	assert.ErrorIs(t, blnkgo.VerifyRequestSignature(req, lookupSigningSecret, time.Minute), blnkgo.ErrInvalidRequestSignature)
+}
+
+func TestVerifyRequestSignature_Expired(t *testing.T) {
+	client := newTestClient(t, nil, blnkgo.WithRequestSigner(blnkgo.NewRequestSigner("key-1", signingSecret)))
+
+	req, err := client.NewRequest("transactions", http.MethodPost, map[string]interface{}{"amount": 10})
+	assert.NoError(t, err)
+	req.Header.Set(blnkgo.SignatureTimestampHeader, "1000")
+
+	assert.ErrorIs(t, blnkgo.VerifyRequestSignature(req, lookupSigningSecret, time.Minute), blnkgo.ErrRequestSignatureExpired)
+}
@@ -0,0 +1,175 @@
+package blnkgo
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// TLSOptions configures the TLS connection to the Blnk server, for example when it sits
+// behind a mutual-TLS gateway.
+type TLSOptions struct {
+	// CertFile and KeyFile hold the PEM client certificate presented to the server
+	CertFile string
+	KeyFile  string
+	// CAFile holds PEM root certificates used instead of the system pool
+	CAFile string
+	// RootCAs is used instead of the system pool, it takes precedence over CAFile
+	RootCAs *x509.CertPool
+	// MinVersion is the minimum TLS version, defaults to TLS 1.2
+	MinVersion uint16
+	// ReloadInterval, when set, re-reads the client certificate from disk at most once per
+	// interval so rotated certificates are picked up without restarting the process
+	ReloadInterval time.Duration
+	// MaxStale is how long the last good certificate is used while the files can not be
+	// reloaded, after that handshakes fail with the reload error. Failed reloads are logged
+	// through the client logger. Defaults to DefaultMaxStaleCredentials, negative never fails.
+	MaxStale time.Duration
+	// ServerName overrides the name used to verify the server certificate
+	ServerName string
+}
+
+// buildTLSConfig turns o into a tls.Config, certificate reload failures are reported to logger
+func (o *TLSOptions) buildTLSConfig(logger Logger) (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: o.ServerName,
+	}
+	if o.MinVersion != 0 {
+		cfg.MinVersion = o.MinVersion
+	}
+
+	switch {
+	case o.RootCAs != nil:
+		cfg.RootCAs = o.RootCAs
+	case o.CAFile != "":
+		pem, err := os.ReadFile(o.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", o.CAFile)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if o.CertFile != "" || o.KeyFile != "" {
+		if o.CertFile == "" || o.KeyFile == "" {
+			return nil, errors.New("both CertFile and KeyFile are required for a client certificate")
+		}
+		maxStale := o.MaxStale
+		if maxStale == 0 {
+			maxStale = DefaultMaxStaleCredentials
+		}
+		reloader, err := newCertReloader(o.CertFile, o.KeyFile, o.ReloadInterval, maxStale, logger)
+		if err != nil {
+			return nil, err
+		}
+		cfg.GetClientCertificate = reloader.GetClientCertificate
+	}
+
+	return cfg, nil
+}
+
+// certReloader serves a client certificate and reloads it when the files change on disk
+type certReloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+	maxStale time.Duration
+	logger   Logger
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	checkedAt time.Time
+	loadedAt  time.Time
+}
+
+func newCertReloader(certFile, keyFile string, interval, maxStale time.Duration, logger Logger) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile, interval: interval, maxStale: maxStale, logger: logger}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	cert, due := r.cert, r.interval > 0 && time.Since(r.checkedAt) >= r.interval
+	r.mu.RUnlock()
+	if !due {
+		return cert, nil
+	}
+
+	//a half-written rotation keeps the previous certificate in use
+	if err := r.reload(); err != nil {
+		r.mu.RLock()
+		loadedAt := r.loadedAt
+		r.mu.RUnlock()
+		if r.logger != nil {
+			r.logger.Error(fmt.Sprintf("unable to reload client certificate %s: %s", r.certFile, err.Error()))
+		}
+		if r.maxStale > 0 && time.Since(loadedAt) > r.maxStale {
+			return nil, fmt.Errorf("client certificate %s was last loaded at %s: %w", r.certFile, loadedAt.Format(time.RFC3339), err)
+		}
+		return cert, nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// reload rereads the files when they changed, a failed check is not retried before the next interval
+func (r *certReloader) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checkedAt = time.Now()
+
+	modTime, err := latestModTime(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	if r.cert == nil || !modTime.Equal(r.modTime) {
+		cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+		if err != nil {
+			return fmt.Errorf("unable to load client certificate: %w", err)
+		}
+		r.cert = &cert
+		r.modTime = modTime
+	}
+	r.loadedAt = r.checkedAt
+	return nil
+}
+
+func latestModTime(paths ...string) (time.Time, error) {
+	var latest time.Time
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// applyTLS installs a transport using o on the client's http.Client
+func (c *Client) applyTLS(o *TLSOptions) error {
+	cfg, err := o.buildTLSConfig(c.options.Logger)
+	if err != nil {
+		return err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = cfg
+	c.client.Transport = transport
+	return nil
+}
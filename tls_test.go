@@ -0,0 +1,201 @@
+package blnkgo_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM certificate and key signed by the CA
+func (ca *testCA) issue(t *testing.T, cn string, usage x509.ExtKeyUsage) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestClient_MutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	serverCertPEM, serverKeyPEM := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	require.NoError(t, err)
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	var seenCN string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenCN = r.TLS.PeerCertificates[0].Subject.CommonName
+		w.Header().Set("Connection", "close")
+		_, _ = w.Write([]byte(`{"ledger_id":"ldg-1","name":"main"}`))
+	}))
+	server.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	start := time.Now().Add(-time.Minute)
+	writeFile(t, caFile, ca.pem, start)
+	certPEM, keyPEM := ca.issue(t, "client-v1", x509.ExtKeyUsageClientAuth)
+	writeFile(t, certFile, certPEM, start)
+	writeFile(t, keyFile, keyPEM, start)
+
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	client := blnkgo.NewClient(baseURL, nil,
+		blnkgo.WithRootCAFile(caFile),
+		blnkgo.WithClientCertificate(certFile, keyFile),
+		blnkgo.WithMinTLSVersion(tls.VersionTLS12),
+		blnkgo.WithCertificateReload(time.Nanosecond),
+	)
+
+	ledger, _, err := client.Ledger.Get("ldg-1")
+	require.NoError(t, err)
+	assert.Equal(t, "ldg-1", ledger.LedgerID)
+	assert.Equal(t, "client-v1", seenCN)
+
+	//rotate the certificate on disk, the next handshake must present it
+	certPEM, keyPEM = ca.issue(t, "client-v2", x509.ExtKeyUsageClientAuth)
+	writeFile(t, certFile, certPEM, time.Now())
+	writeFile(t, keyFile, keyPEM, time.Now())
+
+	_, _, err = client.Ledger.Get("ldg-1")
+	require.NoError(t, err)
+	assert.Equal(t, "client-v2", seenCN)
+}
+
+func TestClient_TLS_InvalidCAFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	writeFile(t, path, []byte("not a certificate"), time.Now())
+
+	client := newTestClient(t, nil, blnkgo.WithRootCAFile(path))
+
+	req, err := client.NewRequest("ledgers", http.MethodGet, nil)
+	assert.Nil(t, req)
+	assert.ErrorContains(t, err, "no certificates found")
+}
+
+func TestClient_TLS_MissingKeyFile(t *testing.T) {
+	client := newTestClient(t, nil, blnkgo.WithTLS(blnkgo.TLSOptions{CertFile: "client.pem"}))
+
+	_, err := client.NewRequest("ledgers", http.MethodGet, nil)
+	assert.ErrorContains(t, err, "both CertFile and KeyFile are required")
+}
+
+func TestClient_TLS_FailedReloadIsLoggedThenReturned(t *testing.T) {
+	ca := newTestCA(t)
+	serverCertPEM, serverKeyPEM := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	serverCert, err := tls.X509KeyPair(serverCertPEM, serverKeyPEM)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Connection", "close")
+		_, _ = w.Write([]byte(`{"ledger_id":"ldg-1"}`))
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{serverCert}, ClientCAs: clientCAs, ClientAuth: tls.RequireAndVerifyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	writeFile(t, caFile, ca.pem, time.Now())
+	certPEM, keyPEM := ca.issue(t, "client", x509.ExtKeyUsageClientAuth)
+	writeFile(t, certFile, certPEM, time.Now())
+	writeFile(t, keyFile, keyPEM, time.Now())
+
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	logger := &recordingLogger{}
+	client := blnkgo.NewClient(baseURL, nil,
+		blnkgo.WithLogger(logger),
+		blnkgo.WithRootCAFile(caFile),
+		blnkgo.WithClientCertificate(certFile, keyFile),
+		blnkgo.WithCertificateReload(time.Nanosecond),
+		blnkgo.WithCertificateMaxStale(100*time.Millisecond),
+	)
+
+	//a broken rotation keeps the last good certificate and is logged
+	require.NoError(t, os.Remove(certFile))
+	_, _, err = client.Ledger.Get("ldg-1")
+	require.NoError(t, err)
+	logger.mu.Lock()
+	require.NotEmpty(t, logger.errors)
+	assert.Contains(t, logger.errors[0], certFile)
+	logger.mu.Unlock()
+
+	//once the certificate is older than the limit handshakes fail
+	time.Sleep(150 * time.Millisecond)
+	_, _, err = client.Ledger.Get("ldg-1")
+	assert.Error(t, err)
+}
@@ -42,12 +42,16 @@ type CreateTransactionRequest struct {
 
 type Transaction struct {
 	ParentTransaction
-	CreatedAt     time.Time `json:"created_at"`
-	TransactionID string    `json:"transaction_id"`
+	CreatedAt           time.Time  `json:"created_at"`
+	TransactionID       string     `json:"transaction_id"`
+	ParentTransactionID string     `json:"parent_transaction,omitempty"`
+	ScheduledFor        *time.Time `json:"scheduled_for,omitempty"`
 }
 
 type UpdateStatus struct {
 	Status InflightStatus `json:"status"`
+	// Amount commits only part of an inflight transaction, zero commits the remaining amount
+	Amount float64 `json:"amount,omitempty"`
 }
 
 func (s *TransactionService) Create(body CreateTransactionRequest) (*Transaction, *http.Response, error) {
@@ -540,3 +540,32 @@ func TestTransactionService_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateTransaction_ValidationErrorListsAllFields(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	body := blnkgo.CreateTransactionRequest{
+		ParentTransaction: blnkgo.ParentTransaction{
+			Amount: -10,
+			Destinations: []blnkgo.Source{
+				{Identifier: "@a", Distribution: "left"},
+				{Identifier: "@b", Distribution: "left"},
+				{Identifier: "@c", Distribution: "abc"},
+			},
+		},
+	}
+
+	transaction, resp, err := svc.Create(body)
+	assert.Nil(t, transaction)
+	assert.Nil(t, resp)
+
+	var verr *blnkgo.ValidationError
+	assert.True(t, errors.As(err, &verr))
+	assert.True(t, verr.HasField("source"))
+	assert.True(t, verr.HasField("amount"))
+	assert.True(t, verr.HasField("destinations[1].distribution"))
+	assert.True(t, verr.HasField("destinations[2].distribution"))
+	assert.Len(t, verr.Errors, 4)
+	assert.Contains(t, err.Error(), "validation error:")
+
+	mockClient.AssertNotCalled(t, "NewRequest")
+}
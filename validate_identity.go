@@ -1,31 +1,100 @@
 package blnkgo
 
-import "errors"
+import "regexp"
+
+var (
+	emailRegex = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	phoneRegex = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+)
 
 // validate fields in Idenity based on the type of identity selected
-func ValidateCreateIdentity(identity Identity) error {
+// every violation, including those found by the extra validators, is collected into a *ValidationError
+func ValidateCreateIdentity(identity Identity, validators ...IdentityValidator) error {
+	verr := &ValidationError{}
 	if identity.IdentityType == Individual {
 		if identity.FirstName == "" {
-			return errors.New("FirstName is required for Individual")
+			verr.Add("first_name", ValidationCodeRequired, "FirstName is required for Individual")
 		}
 		if identity.LastName == "" {
-			return errors.New("LastName is required for Individual")
+			verr.Add("last_name", ValidationCodeRequired, "LastName is required for Individual")
 		}
 		if identity.DOB == nil {
-			return errors.New("DateOfBirth is required for Individual")
+			verr.Add("dob", ValidationCodeRequired, "DateOfBirth is required for Individual")
 		}
 		if identity.Gender == "" {
-			return errors.New("gender is required for Individual")
+			verr.Add("gender", ValidationCodeRequired, "gender is required for Individual")
 		}
 		if identity.Nationality == "" {
-			return errors.New("nationality is required for Individual")
+			verr.Add("nationality", ValidationCodeRequired, "nationality is required for Individual")
 		}
 	} else if identity.IdentityType == Organization {
 		if identity.OrganizationName == "" {
-			return errors.New("organizationName is required for Organization")
+			verr.Add("organization_name", ValidationCodeRequired, "organizationName is required for Organization")
 		}
 	} else {
-		return errors.New("invalid IdentityType")
+		verr.Add("identity_type", ValidationCodeInvalid, "invalid IdentityType")
+	}
+
+	//a partial address is rejected, street requires city and country
+	if identity.Street != "" {
+		if identity.City == "" {
+			verr.Add("city", ValidationCodeRequired, "city is required when street is set")
+		}
+		if identity.Country == "" {
+			verr.Add("country", ValidationCodeRequired, "country is required when street is set")
+		}
+	}
+
+	validateIdentityFormats(identity, verr, validators)
+	return verr.ErrOrNil()
+}
+
+// ValidateUpdateIdentity checks the fields set on a partial update, required fields and
+// complete addresses are not enforced since the server keeps the stored values for fields left empty
+func ValidateUpdateIdentity(identity Identity, validators ...IdentityValidator) error {
+	verr := &ValidationError{}
+	if identity.IdentityType != "" && identity.IdentityType != Individual && identity.IdentityType != Organization {
+		verr.Add("identity_type", ValidationCodeInvalid, "invalid IdentityType")
+	}
+
+	validateIdentityFormats(identity, verr, validators)
+	return verr.ErrOrNil()
+}
+
+// validateIdentityFormats runs the email and phone checks and the validators on the fields
+// that hold real values, tokens returned by the server are left alone
+func validateIdentityFormats(identity Identity, verr *ValidationError, validators []IdentityValidator) {
+	identity = withoutTokenizedValues(identity)
+	if identity.EmailAddress != "" && !emailRegex.MatchString(identity.EmailAddress) {
+		verr.Add("email_address", ValidationCodeInvalid, "email address is not valid")
+	}
+	if identity.PhoneNumber != "" && !phoneRegex.MatchString(identity.PhoneNumber) {
+		verr.Add("phone_number", ValidationCodeInvalid, "phone number must contain 7 to 15 digits with an optional leading +")
+	}
+	for _, v := range validators {
+		v.ValidateIdentity(identity, verr)
+	}
+}
+
+// withoutTokenizedValues clears the fields the identity marks as tokenized
+func withoutTokenizedValues(identity Identity) Identity {
+	for _, field := range identity.TokenizedFields() {
+		switch field {
+		case PIIFieldFirstName:
+			identity.FirstName = ""
+		case PIIFieldLastName:
+			identity.LastName = ""
+		case PIIFieldOtherNames:
+			identity.OtherNames = ""
+		case PIIFieldEmailAddress:
+			identity.EmailAddress = ""
+		case PIIFieldPhoneNumber:
+			identity.PhoneNumber = ""
+		case PIIFieldStreet:
+			identity.Street = ""
+		case PIIFieldPostCode:
+			identity.PostCode = ""
+		}
 	}
-	return nil
+	return identity
 }
@@ -1,66 +1,57 @@
 package blnkgo
 
 import (
-	"errors"
-	"strings"
+	"fmt"
 )
 
+// ValidateCreateTransacation checks a transaction request and returns a *ValidationError
+// listing every violation found, or nil if the request is valid.
 func ValidateCreateTransacation(t CreateTransactionRequest) error {
-	var sb strings.Builder
-	sb.WriteString("validation error:")
+	verr := &ValidationError{}
 	if t.Source != "" && len(t.Sources) > 0 {
-		sb.WriteString("you can not use both Source and Sources")
-		return errors.New(sb.String())
+		verr.Add("source", ValidationCodeConflict, "you can not use both Source and Sources")
 	}
 
 	if t.Source == "" && len(t.Sources) == 0 {
-		sb.WriteString("you must use either Source or Sources")
-		return errors.New(sb.String())
+		verr.Add("source", ValidationCodeRequired, "you must use either Source or Sources")
 	}
 
 	if t.Destination != "" && len(t.Destinations) > 0 {
-		sb.WriteString("you can not use both Destination and Destinations")
-		return errors.New(sb.String())
+		verr.Add("destination", ValidationCodeConflict, "you can not use both Destination and Destinations")
 	}
 
 	if t.Destination == "" && len(t.Destinations) == 0 {
-		sb.WriteString("you must use either Destination or Destinations")
-		return errors.New(sb.String())
+		verr.Add("destination", ValidationCodeRequired, "you must use either Destination or Destinations")
 	}
 
 	if t.Amount < 0 {
-		sb.WriteString("you can not use a negative amount")
-		return errors.New(sb.String())
+		verr.Add("amount", ValidationCodeNegative, "you can not use a negative amount")
 	}
 
 	if len(t.Sources) > 0 {
-		err := validateSources(t.Sources, t.Amount, sb)
-		if err != nil {
-			return err
-		}
+		validateSources("sources", t.Sources, t.Amount, verr)
 	}
 
 	if len(t.Destinations) > 0 {
-		err := validateSources(t.Destinations, t.Amount, sb)
-		if err != nil {
-			return err
-		}
+		validateSources("destinations", t.Destinations, t.Amount, verr)
 	}
 
-	return nil
+	return verr.ErrOrNil()
 }
 
-func validateSources(sources []Source, amount float64, sb strings.Builder) error {
+func validateSources(field string, sources []Source, amount float64, verr *ValidationError) {
 	//total amount of sources  must be equal to the amount
 	total := 0.0
 	hasLeft := false
-	for _, source := range sources {
+	valid := true
+	for i, source := range sources {
+		path := fmt.Sprintf("%s[%d].distribution", field, i)
 		distribution := source.Distribution
 		//check if the distribution is valid
-		isValid := distribution.IsValid()
-		if !isValid {
-			sb.WriteString("invalid distribution")
-			return errors.New(sb.String())
+		if !distribution.IsValid() {
+			verr.Add(path, ValidationCodeInvalid, "invalid distribution in source: "+source.Identifier)
+			valid = false
+			continue
 		}
 
 		switch {
@@ -69,8 +60,9 @@ func validateSources(sources []Source, amount float64, sb strings.Builder) error
 			percentage := distribution.ToPercentage()
 			v := (percentage / 100) * amount
 			if v < 0 {
-				sb.WriteString("invalid distribution in source: " + source.Identifier)
-				return errors.New(sb.String())
+				verr.Add(path, ValidationCodeInvalid, "invalid distribution in source: "+source.Identifier)
+				valid = false
+				continue
 			}
 			total += v
 
@@ -78,40 +70,39 @@ func validateSources(sources []Source, amount float64, sb strings.Builder) error
 			// Get float value from number
 			number := distribution.ToNumber()
 			if number < 0 {
-				sb.WriteString("invalid distribution in source: " + source.Identifier)
-				return errors.New(sb.String())
+				verr.Add(path, ValidationCodeInvalid, "invalid distribution in source: "+source.Identifier)
+				valid = false
+				continue
 			}
 			total += number
 
 		case distribution.IsLeft():
 			// Ensure "left" distribution is used only once
 			if hasLeft {
-				sb.WriteString("you cannot use left distribution more than once")
-				return errors.New(sb.String())
+				verr.Add(path, ValidationCodeDuplicate, "you cannot use left distribution more than once")
+				valid = false
+				continue
 			}
 			hasLeft = true
-
-		default:
-			sb.WriteString("unknown distribution type in source: " + source.Identifier)
-			// Handle invalid or unrecognized distribution
-			return errors.New(sb.String())
 		}
 	}
 
+	// totals are meaningless once an individual distribution is rejected
+	if !valid {
+		return
+	}
+
 	// If "left" distribution is used, calculate its value and add to total
 	if hasLeft {
 		left := amount - total
 		if left < 0 {
-			sb.WriteString("total amount of sources exceeds the amount")
-			return errors.New(sb.String())
+			verr.Add(field, ValidationCodeMismatch, "total amount of "+field+" exceeds the amount")
+			return
 		}
 		total += left
 	}
 
 	if total != amount {
-		sb.WriteString("total amount of sources must be equal to the amount")
-		return errors.New(sb.String())
+		verr.Add(field, ValidationCodeMismatch, "total amount of "+field+" must be equal to the amount")
 	}
-
-	return nil
 }
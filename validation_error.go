@@ -0,0 +1,69 @@
+package blnkgo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validation error codes reported in FieldError.Code.
+const (
+	ValidationCodeRequired  = "required"
+	ValidationCodeConflict  = "conflict"
+	ValidationCodeInvalid   = "invalid"
+	ValidationCodeNegative  = "negative"
+	ValidationCodeDuplicate = "duplicate"
+	ValidationCodeMismatch  = "mismatch"
+)
+
+// FieldError describes a single validation failure on a request field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func (f FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", f.Field, f.Message)
+}
+
+// ValidationError collects every field violation found while validating a request.
+// Use errors.As to retrieve it from the error returned by a service method.
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+func (v *ValidationError) Error() string {
+	msgs := make([]string, 0, len(v.Errors))
+	for _, e := range v.Errors {
+		msgs = append(msgs, e.Error())
+	}
+	return "validation error: " + strings.Join(msgs, "; ")
+}
+
+// Add records a violation for field
+func (v *ValidationError) Add(field, code, message string) {
+	v.Errors = append(v.Errors, FieldError{Field: field, Code: code, Message: message})
+}
+
+// HasErrors reports whether any violation has been recorded
+func (v *ValidationError) HasErrors() bool {
+	return len(v.Errors) > 0
+}
+
+// ErrOrNil returns v as an error if it holds violations, and nil otherwise
+func (v *ValidationError) ErrOrNil() error {
+	if v.HasErrors() {
+		return v
+	}
+	return nil
+}
+
+// HasField reports whether a violation has been recorded for field
+func (v *ValidationError) HasField(field string) bool {
+	for _, e := range v.Errors {
+		if e.Field == field {
+			return true
+		}
+	}
+	return false
+}
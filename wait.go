@@ -0,0 +1,122 @@
+package blnkgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	waitInitialInterval = 250 * time.Millisecond
+	waitMaxInterval     = 5 * time.Second
+)
+
+// TransactionRejectedError is returned by WaitForStatus when the transaction was rejected.
+type TransactionRejectedError struct {
+	Transaction *Transaction
+}
+
+func (e *TransactionRejectedError) Error() string {
+	return fmt.Sprintf("transaction %s was rejected", e.Transaction.TransactionID)
+}
+
+// UnexpectedFinalStatusError is returned by WaitForStatus when the transaction reached a
+// final status that is not one of the targets, so waiting longer can not succeed.
+type UnexpectedFinalStatusError struct {
+	Transaction *Transaction
+	Targets     []PryTransactionStatus
+}
+
+func (e *UnexpectedFinalStatusError) Error() string {
+	return fmt.Sprintf("transaction %s reached final status %q while waiting for %v", e.Transaction.TransactionID, e.Transaction.Status, e.Targets)
+}
+
+// WaitTimeoutError is returned by WaitForStatus when ctx ends before a target status is reached.
+type WaitTimeoutError struct {
+	TransactionID string
+	LastStatus    PryTransactionStatus
+	Err           error
+}
+
+func (e *WaitTimeoutError) Error() string {
+	return fmt.Sprintf("timed out waiting for transaction %s, last status %q: %v", e.TransactionID, e.LastStatus, e.Err)
+}
+
+func (e *WaitTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// WaitForStatus polls a transaction with exponential backoff until its status is one of
+// targetStatuses, and returns the transaction in that state. Without targetStatuses it
+// waits for the transaction to leave QUEUED. A rejected transaction always ends the wait
+// with a *TransactionRejectedError, any other final status that is not a target with an
+// *UnexpectedFinalStatusError, and ctx ending first yields a *WaitTimeoutError.
+func (s *TransactionService) WaitForStatus(ctx context.Context, transactionID string, targetStatuses ...PryTransactionStatus) (*Transaction, error) {
+	if transactionID == "" {
+		return nil, fmt.Errorf("transactionID is required")
+	}
+
+	interval := waitInitialInterval
+	var lastStatus PryTransactionStatus
+	for {
+		transaction, _, err := s.Get(transactionID)
+		switch {
+		case err == nil:
+			lastStatus = transaction.Status
+			if transaction.Status == PryTransactionStatusRejected {
+				return transaction, &TransactionRejectedError{Transaction: transaction}
+			}
+			if isTargetStatus(transaction.Status, targetStatuses) {
+				return transaction, nil
+			}
+			if isFinalStatus(transaction.Status) {
+				return transaction, &UnexpectedFinalStatusError{Transaction: transaction, Targets: targetStatuses}
+			}
+		case !isNotFound(err):
+			//queued transactions may not be readable yet, anything else is final
+			return nil, err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, &WaitTimeoutError{TransactionID: transactionID, LastStatus: lastStatus, Err: ctx.Err()}
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > waitMaxInterval {
+			interval = waitMaxInterval
+		}
+	}
+}
+
+func isTargetStatus(status PryTransactionStatus, targets []PryTransactionStatus) bool {
+	if len(targets) == 0 {
+		return status != PryTransactionStatusQueued && status != ""
+	}
+	for _, target := range targets {
+		if status == target {
+			return true
+		}
+	}
+	return false
+}
+
+// isFinalStatus reports whether a transaction in status can no longer change
+func isFinalStatus(status PryTransactionStatus) bool {
+	switch status {
+	case PryTransactionStatusApplied, PryTransactionStatusRejected, PryTransactionStatusCommit,
+		PryTransactionStatusVoid, PryTransactionStatusExpired:
+		return true
+	}
+	return false
+}
+
+func isNotFound(err error) bool {
+	var apiErr *ApiErrorResponse
+	return errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+}
@@ -0,0 +1,95 @@
+package blnkgo_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// setupStatusSequence makes successive Get calls return the given statuses, repeating the last one
+func setupStatusSequence(m *MockClient, statuses ...blnkgo.PryTransactionStatus) {
+	calls := 0
+	m.On("NewRequest", "transactions/tx-1", http.MethodGet, nil).Return(&http.Request{}, nil)
+	m.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusOK}, nil).Run(func(args mock.Arguments) {
+		status := statuses[len(statuses)-1]
+		if calls < len(statuses) {
+			status = statuses[calls]
+		}
+		calls++
+		*args.Get(1).(*blnkgo.Transaction) = blnkgo.Transaction{
+			TransactionID:     "tx-1",
+			ParentTransaction: blnkgo.ParentTransaction{Status: status},
+		}
+	})
+}
+
+func TestTransactionService_WaitForStatus_Applied(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupStatusSequence(mockClient, blnkgo.PryTransactionStatusQueued, blnkgo.PryTransactionStatusApplied)
+
+	transaction, err := svc.WaitForStatus(context.Background(), "tx-1")
+	assert.NoError(t, err)
+	assert.Equal(t, blnkgo.PryTransactionStatusApplied, transaction.Status)
+	mockClient.AssertNumberOfCalls(t, "CallWithRetry", 2)
+}
+
+func TestTransactionService_WaitForStatus_Rejected(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupStatusSequence(mockClient, blnkgo.PryTransactionStatusRejected)
+
+	transaction, err := svc.WaitForStatus(context.Background(), "tx-1", blnkgo.PryTransactionStatusApplied)
+	var rejected *blnkgo.TransactionRejectedError
+	assert.True(t, errors.As(err, &rejected))
+	assert.Equal(t, "tx-1", rejected.Transaction.TransactionID)
+	assert.Equal(t, blnkgo.PryTransactionStatusRejected, transaction.Status)
+}
+
+func TestTransactionService_WaitForStatus_OtherFinalStatus(t *testing.T) {
+	for _, status := range []blnkgo.PryTransactionStatus{blnkgo.PryTransactionStatusVoid, blnkgo.PryTransactionStatusExpired, blnkgo.PryTransactionStatusApplied} {
+		mockClient, svc := setupTransactionService()
+		setupStatusSequence(mockClient, blnkgo.PryTransactionStatusQueued, status)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		transaction, err := svc.WaitForStatus(ctx, "tx-1", blnkgo.PryTransactionStatusInFlight)
+		cancel()
+
+		var final *blnkgo.UnexpectedFinalStatusError
+		assert.True(t, errors.As(err, &final), status)
+		assert.Equal(t, status, transaction.Status)
+		assert.Equal(t, []blnkgo.PryTransactionStatus{blnkgo.PryTransactionStatusInFlight}, final.Targets)
+		mockClient.AssertNumberOfCalls(t, "CallWithRetry", 2)
+	}
+}
+
+func TestTransactionService_WaitForStatus_Timeout(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	setupStatusSequence(mockClient, blnkgo.PryTransactionStatusQueued)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	transaction, err := svc.WaitForStatus(ctx, "tx-1", blnkgo.PryTransactionStatusApplied)
+	assert.Nil(t, transaction)
+	var timeout *blnkgo.WaitTimeoutError
+	assert.True(t, errors.As(err, &timeout))
+	assert.Equal(t, blnkgo.PryTransactionStatusQueued, timeout.LastStatus)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestTransactionService_WaitForStatus_ServerError(t *testing.T) {
+	mockClient, svc := setupTransactionService()
+	mockClient.On("NewRequest", "transactions/tx-1", http.MethodGet, nil).Return(&http.Request{}, nil)
+	mockClient.On("CallWithRetry", mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusBadRequest},
+		&blnkgo.ApiErrorResponse{Status: http.StatusBadRequest, Message: "bad request"})
+
+	transaction, err := svc.WaitForStatus(context.Background(), "tx-1")
+	assert.Nil(t, transaction)
+	assert.Error(t, err)
+	mockClient.AssertNumberOfCalls(t, "CallWithRetry", 1)
+}
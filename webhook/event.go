@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+)
+
+// EventType identifies the kind of notification delivered by Blnk.
+type EventType string
+
+const (
+	EventTransactionApplied  EventType = "transaction.applied"
+	EventTransactionRejected EventType = "transaction.rejected"
+	EventTransactionInflight EventType = "transaction.inflight"
+	EventTransactionVoid     EventType = "transaction.void"
+	EventTransactionCommit   EventType = "transaction.commit"
+	EventBalanceMonitor      EventType = "balance.monitor"
+)
+
+// IsTransaction reports whether t carries a transaction payload
+func (t EventType) IsTransaction() bool {
+	switch t {
+	case EventTransactionApplied, EventTransactionRejected, EventTransactionInflight, EventTransactionVoid, EventTransactionCommit:
+		return true
+	}
+	return false
+}
+
+// Event is a raw webhook delivery.
+type Event struct {
+	ID         string          `json:"-"`
+	Type       EventType       `json:"event"`
+	Data       json.RawMessage `json:"data"`
+	ReceivedAt time.Time       `json:"-"`
+}
+
+// TransactionEvent is delivered when a transaction changes status.
+type TransactionEvent struct {
+	Event
+	Transaction blnkgo.Transaction
+}
+
+// BalanceMonitorEvent is delivered when a balance monitor condition is met.
+type BalanceMonitorEvent struct {
+	Event
+	Monitor blnkgo.MonitorDataResp
+}
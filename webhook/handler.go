@@ -0,0 +1,241 @@
+// Package webhook receives Blnk webhook deliveries: it verifies their signature, decodes
+// them into typed events and dispatches them to registered handlers.
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+)
+
+const defaultMaxBodyBytes = 1 << 20
+
+type (
+	TransactionHandlerFunc    func(ctx context.Context, event TransactionEvent) error
+	BalanceMonitorHandlerFunc func(ctx context.Context, event BalanceMonitorEvent) error
+	EventHandlerFunc          func(ctx context.Context, event Event) error
+)
+
+// Handler is an http.Handler for Blnk webhook deliveries.
+type Handler struct {
+	secret       string
+	tolerance    time.Duration
+	maxBodyBytes int64
+	store        DedupStore
+	logger       blnkgo.Logger
+	now          func() time.Time
+
+	mu           sync.RWMutex
+	transactions map[EventType][]TransactionHandlerFunc
+	monitors     []BalanceMonitorHandlerFunc
+	fallback     []EventHandlerFunc
+}
+
+type Option func(*Handler)
+
+// WithTolerance sets how far the signed timestamp may drift from now, zero disables the check
+func WithTolerance(tolerance time.Duration) Option {
+	return func(h *Handler) {
+		h.tolerance = tolerance
+	}
+}
+
+// WithDedupStore sets the store used to drop redelivered events
+func WithDedupStore(store DedupStore) Option {
+	return func(h *Handler) {
+		h.store = store
+	}
+}
+
+// WithLogger sets the logger used to report rejected deliveries and handler failures
+func WithLogger(logger blnkgo.Logger) Option {
+	return func(h *Handler) {
+		h.logger = logger
+	}
+}
+
+// WithMaxBodyBytes limits the size of an accepted delivery
+func WithMaxBodyBytes(n int64) Option {
+	return func(h *Handler) {
+		h.maxBodyBytes = n
+	}
+}
+
+// ErrMissingSecret is returned by NewHandler when no signing secret is given
+var ErrMissingSecret = errors.New("webhook: signing secret is required")
+
+// NewHandler returns a Handler that verifies deliveries with secret.
+func NewHandler(secret string, opts ...Option) (*Handler, error) {
+	if secret == "" {
+		return nil, ErrMissingSecret
+	}
+	h := &Handler{
+		secret:       secret,
+		tolerance:    5 * time.Minute,
+		maxBodyBytes: defaultMaxBodyBytes,
+		store:        NewMemoryDedupStore(24 * time.Hour),
+		logger:       blnkgo.NewDefaultLogger(),
+		now:          time.Now,
+		transactions: make(map[EventType][]TransactionHandlerFunc),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h, nil
+}
+
+// OnTransaction registers fn for the given transaction event types
+func (h *Handler) OnTransaction(fn TransactionHandlerFunc, types ...EventType) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, t := range types {
+		h.transactions[t] = append(h.transactions[t], fn)
+	}
+}
+
+// OnBalanceMonitor registers fn for balance monitor events
+func (h *Handler) OnBalanceMonitor(fn BalanceMonitorHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.monitors = append(h.monitors, fn)
+}
+
+// OnEvent registers fn for events no typed handler is registered for
+func (h *Handler) OnEvent(fn EventHandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = append(h.fallback, fn)
+}
+
+// ServeHTTP verifies and dispatches a delivery. Invalid signatures get 401, malformed
+// payloads 400, duplicates are acknowledged without dispatching, and handler failures and
+// redeliveries that arrive while the event is still being handled get 500 and 409 so Blnk
+// redelivers the event.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, h.maxBodyBytes+1))
+	if err != nil {
+		http.Error(w, "unable to read body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > h.maxBodyBytes {
+		http.Error(w, "body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	err = VerifySignature(h.secret, r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader), body, h.tolerance, h.now())
+	if err != nil {
+		h.logger.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := ParseEvent(body)
+	if err != nil {
+		h.logger.Error(err.Error())
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	event.ID = r.Header.Get(EventIDHeader)
+	if event.ID == "" {
+		sum := sha256.Sum256(body)
+		event.ID = hex.EncodeToString(sum[:])
+	}
+	event.ReceivedAt = h.now()
+
+	ctx := r.Context()
+	state, err := h.store.Claim(ctx, event.ID)
+	if err != nil {
+		h.logger.Error(err.Error())
+		http.Error(w, "unable to record event", http.StatusInternalServerError)
+		return
+	}
+	switch state {
+	case ClaimProcessed:
+		w.WriteHeader(http.StatusOK)
+		return
+	case ClaimInProgress:
+		//the first delivery may still fail, so ask for this one to be retried later
+		http.Error(w, "event is being processed", http.StatusConflict)
+		return
+	}
+
+	if err := h.Dispatch(ctx, *event); err != nil {
+		h.logger.Error(fmt.Sprintf("webhook %s (%s) failed: %s", event.ID, event.Type, err.Error()))
+		if forgetErr := h.store.Forget(ctx, event.ID); forgetErr != nil {
+			h.logger.Error(forgetErr.Error())
+		}
+		http.Error(w, "handler failed", http.StatusInternalServerError)
+		return
+	}
+	if err := h.store.MarkProcessed(ctx, event.ID); err != nil {
+		//the handlers succeeded, a failed record only risks a duplicate on redelivery
+		h.logger.Error(err.Error())
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// ParseEvent decodes a raw delivery body
+func ParseEvent(body []byte) (*Event, error) {
+	event := new(Event)
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, fmt.Errorf("webhook: invalid payload: %w", err)
+	}
+	if event.Type == "" {
+		return nil, fmt.Errorf("webhook: payload has no event type")
+	}
+	return event, nil
+}
+
+// Dispatch decodes event into its typed form and calls the registered handlers in order,
+// stopping at the first error.
+func (h *Handler) Dispatch(ctx context.Context, event Event) error {
+	h.mu.RLock()
+	transactionHandlers := h.transactions[event.Type]
+	monitorHandlers := h.monitors
+	fallback := h.fallback
+	h.mu.RUnlock()
+
+	switch {
+	case event.Type.IsTransaction() && len(transactionHandlers) > 0:
+		typed := TransactionEvent{Event: event}
+		if err := json.Unmarshal(event.Data, &typed.Transaction); err != nil {
+			return fmt.Errorf("webhook: invalid transaction payload: %w", err)
+		}
+		for _, fn := range transactionHandlers {
+			if err := fn(ctx, typed); err != nil {
+				return err
+			}
+		}
+	case event.Type == EventBalanceMonitor && len(monitorHandlers) > 0:
+		typed := BalanceMonitorEvent{Event: event}
+		if err := json.Unmarshal(event.Data, &typed.Monitor); err != nil {
+			return fmt.Errorf("webhook: invalid balance monitor payload: %w", err)
+		}
+		for _, fn := range monitorHandlers {
+			if err := fn(ctx, typed); err != nil {
+				return err
+			}
+		}
+	default:
+		for _, fn := range fallback {
+			if err := fn(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	blnkgo "github.com/blnkfinance/blnk-go"
+	"github.com/blnkfinance/blnk-go/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "whsec_test"
+
+type nopLogger struct{}
+
+func (nopLogger) Info(string)  {}
+func (nopLogger) Error(string) {}
+
+func signedRequest(body, id string, at time.Time) *http.Request {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/blnk", strings.NewReader(body))
+	req.Header.Set(webhook.TimestampHeader, ts)
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(secret, ts, []byte(body)))
+	if id != "" {
+		req.Header.Set(webhook.EventIDHeader, id)
+	}
+	return req
+}
+
+func TestHandler_DispatchesTransactionEvent(t *testing.T) {
+	h, err := webhook.NewHandler(secret, webhook.WithLogger(nopLogger{}))
+	assert.NoError(t, err)
+
+	var got []blnkgo.Transaction
+	h.OnTransaction(func(_ context.Context, e webhook.TransactionEvent) error {
+		got = append(got, e.Transaction)
+		return nil
+	}, webhook.EventTransactionApplied)
+
+	body := `{"event":"transaction.applied","data":{"transaction_id":"tx-1","amount":10,"status":"APPLIED"}}`
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(body, "evt-1", time.Now()))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	//redelivery of the same event is acknowledged but not dispatched
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(body, "evt-1", time.Now()))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	assert.Len(t, got, 1)
+	assert.Equal(t, "tx-1", got[0].TransactionID)
+	assert.Equal(t, blnkgo.PryTransactionStatusApplied, got[0].Status)
+}
+
+func TestHandler_DispatchesBalanceMonitorEvent(t *testing.T) {
+	h, err := webhook.NewHandler(secret, webhook.WithLogger(nopLogger{}))
+	assert.NoError(t, err)
+
+	var monitorID string
+	h.OnBalanceMonitor(func(_ context.Context, e webhook.BalanceMonitorEvent) error {
+		monitorID = e.Monitor.MonitorID
+		return nil
+	})
+
+	body := `{"event":"balance.monitor","data":{"monitor_id":"mon-1","balance_id":"bln-1","condition":{"field":"balance","operator":"<","value":100}}}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(body, "", time.Now()))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "mon-1", monitorID)
+}
+
+func TestHandler_RejectsInvalidSignature(t *testing.T) {
+	h, err := webhook.NewHandler(secret, webhook.WithLogger(nopLogger{}))
+	assert.NoError(t, err)
+
+	req := signedRequest(`{"event":"transaction.applied","data":{}}`, "", time.Now())
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign("other", req.Header.Get(webhook.TimestampHeader), []byte("x")))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestHandler_RejectsStaleTimestamp(t *testing.T) {
+	h, err := webhook.NewHandler(secret, webhook.WithLogger(nopLogger{}), webhook.WithTolerance(time.Minute))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(`{"event":"transaction.applied","data":{}}`, "", time.Now().Add(-time.Hour)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestHandler_FailedHandlerAllowsRedelivery(t *testing.T) {
+	h, err := webhook.NewHandler(secret, webhook.WithLogger(nopLogger{}))
+	assert.NoError(t, err)
+
+	calls := 0
+	h.OnTransaction(func(context.Context, webhook.TransactionEvent) error {
+		calls++
+		if calls == 1 {
+			return errors.New("database down")
+		}
+		return nil
+	}, webhook.EventTransactionRejected)
+
+	body := `{"event":"transaction.rejected","data":{"transaction_id":"tx-2"}}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(body, "evt-2", time.Now()))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(body, "evt-2", time.Now()))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestHandler_UnknownEventFallsBack(t *testing.T) {
+	h, err := webhook.NewHandler(secret, webhook.WithLogger(nopLogger{}))
+	assert.NoError(t, err)
+
+	var got webhook.EventType
+	h.OnEvent(func(_ context.Context, e webhook.Event) error {
+		got = e.Type
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(`{"event":"ledger.created","data":{}}`, "", time.Now()))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, webhook.EventType("ledger.created"), got)
+}
+
+func TestHandler_MalformedPayload(t *testing.T) {
+	h, err := webhook.NewHandler(secret, webhook.WithLogger(nopLogger{}))
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(`not json`, "", time.Now()))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_RequiresSecret(t *testing.T) {
+	_, err := webhook.NewHandler("")
+	assert.ErrorIs(t, err, webhook.ErrMissingSecret)
+}
+
+func TestHandler_RedeliveryDuringProcessing(t *testing.T) {
+	h, err := webhook.NewHandler(secret, webhook.WithLogger(nopLogger{}))
+	assert.NoError(t, err)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	calls := 0
+	h.OnTransaction(func(context.Context, webhook.TransactionEvent) error {
+		calls++
+		if calls == 1 {
+			close(started)
+			<-release
+			return errors.New("database down")
+		}
+		return nil
+	}, webhook.EventTransactionApplied)
+
+	body := `{"event":"transaction.applied","data":{"transaction_id":"tx-3"}}`
+	first := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.ServeHTTP(first, signedRequest(body, "evt-3", time.Now()))
+	}()
+	<-started
+
+	//a redelivery while the first attempt runs is not acknowledged
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(body, "evt-3", time.Now()))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	close(release)
+	<-done
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+
+	//the failed event is handled on the next delivery and only then acknowledged as a duplicate
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(body, "evt-3", time.Now()))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, signedRequest(body, "evt-3", time.Now()))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 2, calls)
+}
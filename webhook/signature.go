@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader carries the hex encoded HMAC-SHA256 signature of a delivery
+	SignatureHeader = "X-Blnk-Signature"
+	// TimestampHeader carries the unix time, in seconds, the delivery was signed at
+	TimestampHeader = "X-Blnk-Timestamp"
+	// EventIDHeader carries the delivery id used to deduplicate redelivered events
+	EventIDHeader = "X-Blnk-Event-Id"
+)
+
+var (
+	ErrMissingSignature = errors.New("webhook: missing signature or timestamp")
+	ErrInvalidSignature = errors.New("webhook: invalid signature")
+	ErrExpiredTimestamp = errors.New("webhook: timestamp outside tolerance")
+)
+
+// Sign returns the signature of body for the given unix timestamp.
+// The signed message is "<timestamp>.<body>".
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature checks signature against body and rejects timestamps further than
+// tolerance from now. A zero tolerance disables the timestamp check.
+func VerifySignature(secret, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	if signature == "" || timestamp == "" {
+		return ErrMissingSignature
+	}
+
+	if tolerance > 0 {
+		unix, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return ErrExpiredTimestamp
+		}
+		diff := now.Sub(time.Unix(unix, 0))
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > tolerance {
+			return ErrExpiredTimestamp
+		}
+	}
+
+	expected, err := hex.DecodeString(Sign(secret, timestamp, body))
+	if err != nil {
+		return err
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, got) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
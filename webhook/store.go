@@ -0,0 +1,81 @@
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ClaimState reports what a DedupStore already knew about an event id.
+type ClaimState int
+
+const (
+	// ClaimNew means the id was not recorded and is now claimed by the caller
+	ClaimNew ClaimState = iota
+	// ClaimInProgress means another delivery of the id is still being handled
+	ClaimInProgress
+	// ClaimProcessed means the id was already handled successfully
+	ClaimProcessed
+)
+
+// DedupStore remembers event ids so redelivered events are only handled once. An id is
+// claimed before its handlers run and only marked processed once they succeed.
+type DedupStore interface {
+	// Claim records id as in progress unless it was already recorded, and reports its previous state
+	Claim(ctx context.Context, id string) (ClaimState, error)
+	// MarkProcessed records a claimed id as handled
+	MarkProcessed(ctx context.Context, id string) error
+	// Forget removes id so a failed event can be handled again on redelivery
+	Forget(ctx context.Context, id string) error
+}
+
+type dedupEntry struct {
+	at        time.Time
+	processed bool
+}
+
+// MemoryDedupStore is an in-process DedupStore that forgets ids after ttl.
+type MemoryDedupStore struct {
+	mu   sync.Mutex
+	ttl  time.Duration
+	seen map[string]dedupEntry
+}
+
+func NewMemoryDedupStore(ttl time.Duration) *MemoryDedupStore {
+	return &MemoryDedupStore{ttl: ttl, seen: make(map[string]dedupEntry)}
+}
+
+func (s *MemoryDedupStore) Claim(_ context.Context, id string) (ClaimState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range s.seen {
+		if now.Sub(entry.at) > s.ttl {
+			delete(s.seen, k)
+		}
+	}
+
+	if entry, ok := s.seen[id]; ok {
+		if entry.processed {
+			return ClaimProcessed, nil
+		}
+		return ClaimInProgress, nil
+	}
+	s.seen[id] = dedupEntry{at: now}
+	return ClaimNew, nil
+}
+
+func (s *MemoryDedupStore) MarkProcessed(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen[id] = dedupEntry{at: time.Now(), processed: true}
+	return nil
+}
+
+func (s *MemoryDedupStore) Forget(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.seen, id)
+	return nil
+}